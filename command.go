@@ -2,8 +2,10 @@ package goNixArgParser
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"path"
+	"strings"
 )
 
 func NewCommand(
@@ -38,16 +40,61 @@ func (c *Command) NewSubCommand(
 	restsSigns, groupSeps, assignSigns, undefFlagPrefixes []string,
 ) *Command {
 	subCommand := NewCommand(names, summary, mergeFlagPrefix, restsSigns, groupSeps, assignSigns, undefFlagPrefixes)
-	c.subCommands = append(c.subCommands, subCommand)
+	c.addSubCommand(subCommand) // never fails for new command without options
 	return subCommand
 }
 
 func (c *Command) NewSimpleSubCommand(name, summary string, aliasNames ...string) *Command {
 	subCommand := NewSimpleCommand(name, summary, aliasNames...)
-	c.subCommands = append(c.subCommands, subCommand)
+	c.addSubCommand(subCommand) // never fails for new command without options
 	return subCommand
 }
 
+func (c *Command) getInjectOptions() []*Option {
+	options := []*Option{}
+	for _, opt := range []*Option{c.helpOption, c.helpAllOption, c.versionOption} {
+		if opt != nil {
+			options = append(options, opt)
+		}
+	}
+	return options
+}
+
+func (c *Command) addSubCommand(subCommand *Command) error {
+	for _, opt := range c.getInjectOptions() {
+		if err := subCommand.verifyInjectOption(opt); err != nil {
+			return err
+		}
+	}
+
+	subCommand.parent = c
+	c.subCommands = append(c.subCommands, subCommand)
+
+	if c.helpOption != nil {
+		if err := subCommand.injectHelp(*c.helpOption); err != nil {
+			return err
+		}
+	}
+	if c.helpAllOption != nil {
+		if err := subCommand.injectHelpAll(*c.helpAllOption); err != nil {
+			return err
+		}
+	}
+	if c.versionOption != nil {
+		if err := subCommand.injectVersion(c.version, *c.versionOption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Command) AddSubCommand(subCommand *Command) error {
+	if subCommand.parent != nil {
+		return errors.New("command '" + subCommand.Name() + "' already has parent command")
+	}
+	return c.addSubCommand(subCommand)
+}
+
 func (c *Command) hasName(name string) bool {
 	for _, n := range c.names {
 		if n == name {
@@ -92,6 +139,142 @@ func (c *Command) SubCommands() []*Command {
 	return c.subCommands
 }
 
+func (c *Command) SetSubCommandRequired(required bool) {
+	c.subCommandRequired = required
+}
+
+func (c *Command) SubCommandRequired() bool {
+	return c.subCommandRequired
+}
+
+func (c *Command) Parent() *Command {
+	return c.parent
+}
+
+func (c *Command) cmdPaths() []string {
+	var cmdPaths []string
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		cmdPaths = append([]string{cmd.Name()}, cmdPaths...)
+	}
+	return cmdPaths
+}
+
+func (c *Command) docPaths() []string {
+	cmdPaths := c.cmdPaths()
+	cmdPaths[0] = path.Base(cmdPaths[0])
+	return cmdPaths
+}
+
+func (c *Command) docName() string {
+	return strings.Join(c.docPaths(), "-")
+}
+
+func (c *Command) usageArgs() string {
+	usages := []string{"[options]"}
+	if len(c.subCommands) > 0 {
+		usages = append(usages, "[command]")
+	}
+	for _, p := range c.options.positionals {
+		usages = append(usages, p.usage())
+	}
+	return strings.Join(usages, " ")
+}
+
+func (c *Command) walk(cmdPaths []string, fn func(cmd *Command, cmdPaths []string)) {
+	cmdPaths = append(cmdPaths[:len(cmdPaths):len(cmdPaths)], c.Name())
+	fn(c, cmdPaths)
+
+	for _, subCmd := range c.subCommands {
+		subCmd.walk(cmdPaths, fn)
+	}
+}
+
+func (c *Command) inheritedOptions() []*Option {
+	var ancestors []*Command
+	for p := c.parent; p != nil; p = p.parent {
+		ancestors = append([]*Command{p}, ancestors...)
+	}
+
+	options := []*Option{}
+	for _, ancestor := range ancestors {
+		for _, opt := range ancestor.options.options {
+			if opt.Persistent {
+				options = append(options, opt)
+			}
+		}
+	}
+	return options
+}
+
+func (c *Command) globalOptions() []*Option {
+	options := []*Option{}
+	for _, opt := range c.inheritedOptions() {
+		if !opt.Hidden && c.options.keyOptionMap[opt.Key] == nil {
+			options = append(options, opt)
+		}
+	}
+	return options
+}
+
+func (c *Command) effectiveOptions() *OptionSet {
+	inheritedOptions := c.inheritedOptions()
+	if len(inheritedOptions) == 0 {
+		return c.options
+	}
+
+	s := c.options.clone()
+	for _, opt := range inheritedOptions {
+		s.Add(*opt) // options defined by the command itself take precedence
+	}
+	s.constraints = append(append([]*constraint{}, s.constraints...), c.inheritedConstraints(s)...)
+	return s
+}
+
+func (c *Command) inheritedConstraints(s *OptionSet) []*constraint {
+	constraints := []*constraint{}
+	for p := c.parent; p != nil; p = p.parent {
+		for _, ct := range p.options.constraints {
+			if p.options.isInheritedBy(ct, c.options, s) {
+				constraints = append(constraints, ct)
+			}
+		}
+	}
+	return constraints
+}
+
+func (c *Command) subCommandNames() []string {
+	names := []string{}
+	for _, cmd := range c.subCommands {
+		names = append(names, cmd.names...)
+	}
+	return names
+}
+
+func (c *Command) getUndefCommandError(args []string, offset int) *ParseError {
+	if len(c.subCommands) == 0 || len(c.options.positionals) > 0 || len(args) == 0 {
+		return nil
+	}
+
+	arg := args[0]
+	s := c.options
+	if s.nameFlagMap[arg] != nil || s.isUdefFlag(arg) || s.isRestSign(arg) || s.isGroupSep(arg) {
+		return nil
+	}
+
+	suggestions := getSuggestions(arg, c.subCommandNames())
+	if len(suggestions) == 0 && !c.subCommandRequired {
+		return nil
+	}
+
+	return &ParseError{
+		Kind:        UndefCommandError,
+		Arg:         arg,
+		Index:       offset,
+		Source:      FlagSource,
+		Suggestions: suggestions,
+	}
+}
+
 func (c *Command) getLeafCmd(args []string) (explicitCmd *Command, inferredCmd *Command, cmdPaths []string) {
 	inferredCmd = c
 
@@ -118,16 +301,19 @@ func (c *Command) getLeafCmd(args []string) (explicitCmd *Command, inferredCmd *
 func (c *Command) extractCmdOptionArgs(specifiedArgs, configArgs []string) (
 	specifiedCmd *Command,
 	cmdPaths, specifiedOptionArgs, configOptionArgs []string,
+	specifiedOffset, configOffset int,
 ) {
 	_, specifiedCmd, specifiedCmdPaths := c.getLeafCmd(specifiedArgs)
 	explicitConfigCmd, configCmd, configCmdPaths := c.getLeafCmd(configArgs)
 
 	cmdPaths = specifiedCmdPaths
 
-	specifiedOptionArgs = specifiedArgs[len(specifiedCmdPaths):]
+	specifiedOffset = len(specifiedCmdPaths)
+	specifiedOptionArgs = specifiedArgs[specifiedOffset:]
 
 	if specifiedCmd == configCmd {
-		configOptionArgs = configArgs[len(configCmdPaths):]
+		configOffset = len(configCmdPaths)
+		configOptionArgs = configArgs[configOffset:]
 	} else if explicitConfigCmd == nil {
 		configOptionArgs = configArgs
 	} else {
@@ -137,33 +323,63 @@ func (c *Command) extractCmdOptionArgs(specifiedArgs, configArgs []string) (
 	return
 }
 
-func (c *Command) Parse(specifiedArgs, configArgs []string) *ParseResult {
-	cmd, cmdPaths, specifiedOptionArgs, configOptionArgs := c.extractCmdOptionArgs(specifiedArgs, configArgs)
-	result := cmd.options.Parse(specifiedOptionArgs, configOptionArgs)
-	result.commands = cmdPaths
-
-	return result
-}
+func (c *Command) parseOptionArgs(
+	specifiedOptionArgs, configOptionArgs []string,
+	specifiedOffset, configOffset int,
+	groups bool,
+) []*ParseResult {
+	s := c.effectiveOptions()
 
-func (c *Command) ParseGroups(specifiedArgs, configArgs []string) (results []*ParseResult) {
-	cmd, cmdPaths, specifiedOptionArgs, configOptionArgs := c.extractCmdOptionArgs(specifiedArgs, configArgs)
-
-	if len(specifiedOptionArgs) == 0 && len(configOptionArgs) == 0 {
-		result := cmd.options.Parse(specifiedOptionArgs, configOptionArgs)
-		results = append(results, result)
-	} else {
-		results = cmd.options.ParseGroups(specifiedOptionArgs, configOptionArgs)
+	if !groups || (len(specifiedOptionArgs) == 0 && len(configOptionArgs) == 0) {
+		return []*ParseResult{s.parseAt(specifiedOptionArgs, configOptionArgs, specifiedOffset, configOffset)}
 	}
 
+	return s.parseGroupsAt(specifiedOptionArgs, configOptionArgs, specifiedOffset, configOffset)
+}
+
+func (c *Command) fillResults(results []*ParseResult, cmdPaths, specifiedOptionArgs []string, specifiedOffset int) {
 	for _, result := range results {
 		result.commands = cmdPaths
+		result.command = c
+	}
+	if parseError := c.getUndefCommandError(specifiedOptionArgs, specifiedOffset); parseError != nil {
+		if c.subCommandRequired {
+			results[0].parseErrors = append([]*ParseError{parseError}, results[0].parseErrors...)
+		} else {
+			results[0].undefCommand = parseError
+		}
 	}
+}
+
+func (c *Command) parse(specifiedArgs, configArgs []string, groups bool) []*ParseResult {
+	if c.interleaved {
+		return c.parseInterleaved(specifiedArgs, configArgs, groups)
+	}
+
+	cmd, cmdPaths, specifiedOptionArgs, configOptionArgs, specifiedOffset, configOffset := c.extractCmdOptionArgs(specifiedArgs, configArgs)
+	results := cmd.parseOptionArgs(specifiedOptionArgs, configOptionArgs, specifiedOffset, configOffset, groups)
+	cmd.fillResults(results, cmdPaths, specifiedOptionArgs, specifiedOffset)
 
 	return results
 }
 
-func (c *Command) OutputHelp(w io.Writer) {
-	newline := []byte{'\n'}
+func (c *Command) Parse(specifiedArgs, configArgs []string) *ParseResult {
+	return c.parse(specifiedArgs, configArgs, false)[0]
+}
+
+func (c *Command) ParseStrict(specifiedArgs, configArgs []string) (*ParseResult, error) {
+	result := c.Parse(specifiedArgs, configArgs)
+	if result.HelpRequested() || result.VersionRequested() {
+		return result, nil
+	}
+	return result, ErrorList(result.GetErrors()).orNil()
+}
+
+func (c *Command) ParseGroups(specifiedArgs, configArgs []string) []*ParseResult {
+	return c.parse(specifiedArgs, configArgs, true)
+}
+
+func (c *Command) outputHelp(w io.Writer, all bool) {
 	buffer := &bytes.Buffer{}
 
 	name := c.Name()
@@ -180,19 +396,40 @@ func (c *Command) OutputHelp(w io.Writer) {
 		io.WriteString(w, "Usage:\n")
 	}
 
-	io.WriteString(w, "\nOptions:\n\n")
-	c.options.OutputHelp(w)
+	formatter := c.getHelpFormatter()
 
-	if len(c.subCommands) > 0 {
-		io.WriteString(w, "\nSub commands:\n\n")
-		for _, cmd := range c.subCommands {
-			io.WriteString(w, cmd.Name())
-			w.Write(newline)
-			if len(cmd.summary) > 0 {
-				io.WriteString(w, cmd.summary)
-				w.Write(newline)
-			}
-			w.Write(newline)
+	sections, collapsed := c.options.helpSections("Options", all)
+	for _, section := range sections {
+		formatter.OutputOptions(w, section.title, section.options)
+	}
+
+	globalOptions := []*Option{}
+	for _, opt := range c.globalOptions() {
+		if !opt.isCollapsed(all) {
+			globalOptions = append(globalOptions, opt)
 		}
 	}
+	if len(globalOptions) > 0 {
+		formatter.OutputOptions(w, "Global options", globalOptions)
+	}
+
+	if len(c.options.positionals) > 0 {
+		formatter.OutputPositionals(w, "Arguments", c.options.positionals)
+	}
+
+	if len(c.subCommands) > 0 {
+		formatter.OutputCommands(w, "Sub commands", c.subCommands)
+	}
+
+	if len(collapsed) > 0 && c.helpAllOption != nil {
+		io.WriteString(w, "\nUse "+c.helpAllOption.Flags[0].Name+" to show all options, including: "+strings.Join(collapsed, ", ")+"\n")
+	}
+}
+
+func (c *Command) OutputHelp(w io.Writer) {
+	c.outputHelp(w, false)
+}
+
+func (c *Command) OutputHelpAll(w io.Writer) {
+	c.outputHelp(w, true)
 }
@@ -0,0 +1,188 @@
+package goNixArgParser
+
+import (
+	"io"
+	"strings"
+)
+
+const CompleteCommandName = "__complete"
+
+func filterCompletions(candidates []string, prefix string) []string {
+	results := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			results = appendUnique(results, candidate)
+		}
+	}
+	return results
+}
+
+func (s *OptionSet) hasDynamicCompletion() bool {
+	for _, opt := range s.options {
+		if opt.Complete != nil {
+			return true
+		}
+	}
+	for _, p := range s.positionals {
+		if p.Complete != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) hasDynamicCompletion() (dynamic bool) {
+	c.walk(nil, func(cmd *Command, _ []string) {
+		if cmd.options.hasDynamicCompletion() {
+			dynamic = true
+		}
+	})
+	return
+}
+
+func (s *OptionSet) isFlagLike(input string) bool {
+	for _, prefix := range s.undefFlagPrefixes {
+		if len(prefix) > 0 && strings.HasPrefix(input, prefix) {
+			return true
+		}
+	}
+	return len(s.mergeFlagPrefix) > 0 && strings.HasPrefix(input, s.mergeFlagPrefix)
+}
+
+func (s *OptionSet) getAssigningOption(input string) (opt *Option, assigned, prefix string) {
+	for _, assignSign := range s.assignSigns {
+		if len(assignSign) == 0 {
+			continue
+		}
+		assignIndex := strings.Index(input, assignSign)
+		if assignIndex <= 0 {
+			continue
+		}
+		flag := s.nameFlagMap[input[:assignIndex]]
+		if flag == nil {
+			flag, _ = s.findFlagByPrefix(input[:assignIndex])
+		}
+		if flag == nil || !s.flagOptionMap[flag.Name].AcceptValue {
+			continue
+		}
+		valueIndex := assignIndex + len(assignSign)
+		return s.flagOptionMap[flag.Name], input[:valueIndex], input[valueIndex:]
+	}
+	return nil, "", ""
+}
+
+func (s *OptionSet) getPendingValueOption(tokens []*argToken) *Option {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := tokens[i]
+		switch token.kind {
+		case valueArg:
+			continue
+		case flagArg:
+			opt := s.flagOptionMap[token.text]
+			if !opt.AcceptValue || !s.nameFlagMap[token.text].canFollowAssign {
+				return nil
+			}
+			if i == len(tokens)-1 || opt.MultiValues {
+				return opt
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *OptionSet) getPositionalAt(index int) *Positional {
+	for i, p := range s.positionals {
+		if i == index || (p.Variadic && i < index) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *Command) getCompletionLevel(words []string) (cmd *Command, optionArgs []string) {
+	if c.interleaved {
+		levels, _, _ := c.getInterleavedLevels(words)
+		leafLevel := levels[len(levels)-1]
+		return leafLevel.cmd, leafLevel.args
+	}
+
+	_, cmd, cmdPaths := c.getLeafCmd(words)
+	return cmd, words[len(cmdPaths):]
+}
+
+func (c *Command) Complete(args []string) []string {
+	if len(args) < 2 {
+		return []string{}
+	}
+
+	words := make([]string, 0, len(args))
+	if len(c.names) > 0 {
+		words = append(words, c.Name())
+	}
+	words = append(words, args[1:len(args)-1]...)
+	current := args[len(args)-1]
+
+	results := c.ParseGroups(words, nil)
+	result := results[len(results)-1]
+
+	cmd, optionArgs := c.getCompletionLevel(words)
+	s := cmd.effectiveOptions()
+
+	tokensGroups := s.argsToTokensGroups(optionArgs, 0)
+	tokens := s.prepareTokens(tokensGroups[len(tokensGroups)-1])
+	_, _, rests, _, _, _ := s.walkTokens(tokens)
+
+	foundRestSign := false
+	for _, token := range tokens {
+		if token.kind == restSignArg {
+			foundRestSign = true
+			break
+		}
+	}
+
+	if !foundRestSign {
+		if opt, assigned, prefix := s.getAssigningOption(current); opt != nil {
+			if opt.Complete == nil {
+				return []string{}
+			}
+			candidates := filterCompletions(opt.Complete(result, prefix), prefix)
+			for i := range candidates {
+				candidates[i] = assigned + candidates[i]
+			}
+			return candidates
+		}
+
+		if s.isFlagLike(current) {
+			return filterCompletions(s.visibleFlagNames(), current)
+		}
+
+		if opt := s.getPendingValueOption(tokens); opt != nil {
+			if opt.Complete == nil {
+				return []string{}
+			}
+			return filterCompletions(opt.Complete(result, current), current)
+		}
+	}
+
+	candidates := []string{}
+	if !foundRestSign && len(rests) == 0 && (c.interleaved || len(optionArgs) == 0) {
+		for _, subCmd := range cmd.subCommands {
+			candidates = append(candidates, subCmd.names...)
+		}
+	}
+	if p := s.getPositionalAt(len(rests)); p != nil && p.Complete != nil {
+		candidates = append(candidates, p.Complete(result, current)...)
+	}
+
+	return filterCompletions(candidates, current)
+}
+
+func (c *Command) OutputCompletions(w io.Writer, args []string) {
+	for _, candidate := range c.Complete(args) {
+		io.WriteString(w, candidate)
+		io.WriteString(w, "\n")
+	}
+}
@@ -0,0 +1,109 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func getCompleteCommand() *Command {
+	cmd := getGitCommand()
+	cmdSetUrl := cmd.GetSubCommand("remote").GetSubCommand("set-url")
+
+	cmdSetUrl.options.keyOptionMap["dummy"].Complete = func(result *ParseResult, prefix string) []string {
+		return []string{"alpha", "beta", "bravo"}
+	}
+	cmdSetUrl.options.AddPositional(Positional{
+		Name: "name",
+		Complete: func(result *ParseResult, prefix string) []string {
+			if result.HasFlagKey("push") {
+				return []string{"push-origin"}
+			}
+			return []string{"origin", "upstream"}
+		},
+	})
+	cmdSetUrl.options.AddPositional(Positional{Name: "url"})
+
+	return cmd
+}
+
+func expectCompletions(t *testing.T, cmd *Command, args []string, expects ...string) {
+	t.Helper()
+	candidates := cmd.Complete(args)
+	if len(candidates) != len(expects) {
+		t.Error(args, candidates)
+		return
+	}
+	for i := range expects {
+		if candidates[i] != expects[i] {
+			t.Error(args, candidates)
+			return
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cmd := getCompleteCommand()
+
+	expectCompletions(t, cmd, []string{"git", ""}, "remote", "rmt", "rt", "reset")
+	expectCompletions(t, cmd, []string{"git", "re"}, "remote", "reset")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--d"}, "--dummy", "--dummy-x")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--dummy", "b"}, "beta", "bravo")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--dummy=a"}, "--dummy=alpha")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--dummy-x", ""})
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", ""}, "origin", "upstream")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--push", "--dummy", "x", ""}, "push-origin")
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "origin", ""})
+	expectCompletions(t, cmd, []string{"git", "rmt", "set-url", "--", "u"}, "upstream")
+}
+
+func TestCompleteInterleaved(t *testing.T) {
+	cmd := getCompleteCommand()
+	cmd.GetSubCommand("remote").options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"--verbose"}), Persistent: true})
+	cmd.SetInterleaved(true)
+
+	expectCompletions(t, cmd, []string{"git", "remote", "--verbose", ""}, "set-url")
+	expectCompletions(t, cmd, []string{"git", "remote", "--verbose", "set-url", "--dummy", "a"}, "alpha")
+}
+
+func TestExecuteComplete(t *testing.T) {
+	cmd := getCompleteCommand()
+	buffer := &bytes.Buffer{}
+	cmd.SetOutput(buffer)
+
+	if err := cmd.Execute([]string{"./git", CompleteCommandName, "remote", "set-url", "--dummy", ""}); err != nil {
+		t.Error(err)
+	}
+	if buffer.String() != "alpha\nbeta\nbravo\n" {
+		t.Error(buffer.String())
+	}
+}
+
+func TestDynamicCompletionScripts(t *testing.T) {
+	cmd := getCompleteCommand()
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputBashCompletion(buffer)
+	if !strings.Contains(buffer.String(), `mapfile -t COMPREPLY < <("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)`) {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	cmd.OutputZshCompletion(buffer)
+	if !strings.Contains(buffer.String(), "'(--dummy)--dummy[dummy option]:value:_git_dynamic'") ||
+		!strings.Contains(buffer.String(), "'*:arg:_git_dynamic'") {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	cmd.OutputFishCompletion(buffer)
+	if !strings.Contains(buffer.String(), "-l 'dummy' -r -f -a '(__git_dynamic)' -d 'dummy option'") {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	getGitCommand().OutputBashCompletion(buffer)
+	if strings.Contains(buffer.String(), CompleteCommandName) {
+		t.Error("static commands should not call back into binary")
+	}
+}
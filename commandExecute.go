@@ -0,0 +1,61 @@
+package goNixArgParser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+)
+
+var ErrNoHandler = errors.New("command has no handler")
+
+func (c *Command) SetHandler(handler Handler) {
+	c.handler = handler
+}
+
+func (c *Command) Handler() Handler {
+	return c.handler
+}
+
+func (c *Command) SetOutput(w io.Writer) {
+	c.output = w
+}
+
+func (c *Command) getOutput() io.Writer {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if cmd.output != nil {
+			return cmd.output
+		}
+	}
+	return os.Stdout
+}
+
+func (c *Command) ExecuteContext(ctx context.Context, specifiedArgs, configArgs []string) error {
+	if len(specifiedArgs) > 1 && specifiedArgs[1] == CompleteCommandName {
+		c.OutputCompletions(c.getOutput(), append(specifiedArgs[:1:1], specifiedArgs[2:]...))
+		return nil
+	}
+
+	result, err := c.ParseStrict(specifiedArgs, configArgs)
+
+	switch {
+	case result.HelpRequested():
+		result.OutputHelp(c.getOutput())
+		return nil
+	case result.VersionRequested():
+		result.OutputVersion(c.getOutput())
+		return nil
+	case err != nil:
+		return err
+	}
+
+	handler := result.command.handler
+	if handler == nil {
+		return ErrNoHandler
+	}
+	return handler(ctx, result)
+}
+
+func (c *Command) Execute(args []string) error {
+	return c.ExecuteContext(context.Background(), args, nil)
+}
@@ -0,0 +1,59 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	cmd := NewSimpleCommand("app", "")
+	cmd.EnableHelp()
+	cmdRemote := cmd.NewSimpleSubCommand("remote", "")
+	cmdAdd := cmdRemote.NewSimpleSubCommand("add", "")
+	cmdAdd.options.AddPositional(Positional{Name: "name", Required: true})
+
+	type ctxKey struct{}
+	var added string
+	cmdAdd.SetHandler(func(ctx context.Context, result *ParseResult) error {
+		if ctx.Value(ctxKey{}) != "ctxValue" {
+			t.Error("context not passed")
+		}
+		added, _ = result.GetPositional("name")
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "ctxValue")
+	if err := cmd.ExecuteContext(ctx, []string{"app", "remote", "add", "origin"}, nil); err != nil {
+		t.Error(err)
+	}
+	if added != "origin" {
+		t.Error(added)
+	}
+
+	if err := cmd.Execute([]string{"app", "remote", "add"}); err == nil {
+		t.Error("should fail on missing positional")
+	}
+
+	if err := cmd.Execute([]string{"app", "remote"}); !errors.Is(err, ErrNoHandler) {
+		t.Error(err)
+	}
+
+	buffer := &bytes.Buffer{}
+	cmd.SetOutput(buffer)
+	if err := cmd.Execute([]string{"app", "remote", "add", "--help"}); err != nil {
+		t.Error(err)
+	}
+	if buffer.Len() == 0 {
+		t.Error("help should be output")
+	}
+
+	handlerErr := errors.New("handler error")
+	cmdRemote.SetHandler(func(ctx context.Context, result *ParseResult) error {
+		return handlerErr
+	})
+	if err := cmd.Execute([]string{"app", "remote"}); err != handlerErr {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,137 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	HelpKey    = "help"
+	HelpAllKey = "helpAll"
+	VersionKey = "version"
+)
+
+func (c *Command) verifyInjectOption(opt *Option) error {
+	if err := c.options.verify(opt); err != nil {
+		return errors.New("command '" + c.Name() + "': " + err.Error())
+	}
+
+	for _, subCmd := range c.subCommands {
+		if err := subCmd.verifyInjectOption(opt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Command) addInjectOption(opt Option, assign func(cmd *Command, opt *Option)) {
+	c.options.Add(opt) // already verified
+	assign(c, &opt)
+
+	for _, subCmd := range c.subCommands {
+		subCmd.addInjectOption(opt, assign)
+	}
+}
+
+func (c *Command) injectOption(opt Option, assign func(cmd *Command, opt *Option)) error {
+	if err := c.verifyInjectOption(&opt); err != nil {
+		return err
+	}
+	c.addInjectOption(opt, assign)
+	return nil
+}
+
+func (c *Command) injectHelp(opt Option) error {
+	return c.injectOption(opt, func(cmd *Command, opt *Option) {
+		cmd.helpOption = opt
+	})
+}
+
+func (c *Command) injectHelpAll(opt Option) error {
+	return c.injectOption(opt, func(cmd *Command, opt *Option) {
+		cmd.helpAllOption = opt
+	})
+}
+
+func (c *Command) injectVersion(version string, opt Option) error {
+	return c.injectOption(opt, func(cmd *Command, opt *Option) {
+		cmd.versionOption = opt
+		cmd.version = version
+	})
+}
+
+func (c *Command) EnableHelp(flags ...string) error {
+	if len(flags) == 0 {
+		flags = []string{"-h", "--help"}
+	}
+	return c.injectHelp(NewFlagsOption(HelpKey, flags, "", "show help"))
+}
+
+func (c *Command) EnableHelpAll(flags ...string) error {
+	if len(flags) == 0 {
+		flags = []string{"--help-all"}
+	}
+	return c.injectHelpAll(NewFlagsOption(HelpAllKey, flags, "", "show help of all options"))
+}
+
+func (c *Command) EnableVersion(version string, flags ...string) error {
+	if len(flags) == 0 {
+		flags = []string{"--version"}
+	}
+	return c.injectVersion(version, NewFlagsOption(VersionKey, flags, "", "show version"))
+}
+
+func (c *Command) Version() string {
+	return c.version
+}
+
+// =============================
+// parse result
+// =============================
+
+func (r *ParseResult) isBuiltinKey(key string) bool {
+	switch key {
+	case HelpKey:
+		return r.command != nil && r.command.helpOption != nil
+	case HelpAllKey:
+		return r.command != nil && r.command.helpAllOption != nil
+	case VersionKey:
+		return r.command != nil && r.command.versionOption != nil
+	default:
+		return false
+	}
+}
+
+func (r *ParseResult) HelpRequested() bool {
+	return (r.command != nil && r.command.helpOption != nil && r.HasFlagKey(HelpKey)) || r.HelpAllRequested()
+}
+
+func (r *ParseResult) HelpAllRequested() bool {
+	return r.command != nil && r.command.helpAllOption != nil && r.HasFlagKey(HelpAllKey)
+}
+
+func (r *ParseResult) VersionRequested() bool {
+	return r.command != nil && r.command.versionOption != nil && r.HasFlagKey(VersionKey)
+}
+
+func (r *ParseResult) Command() *Command {
+	return r.command
+}
+
+func (r *ParseResult) OutputHelp(w io.Writer) {
+	if r.command == nil {
+		return
+	}
+	if r.HelpAllRequested() {
+		r.command.OutputHelpAll(w)
+	} else {
+		r.command.OutputHelp(w)
+	}
+}
+
+func (r *ParseResult) OutputVersion(w io.Writer) {
+	if r.command != nil && len(r.command.version) > 0 {
+		io.WriteString(w, r.command.version)
+		w.Write([]byte{'\n'})
+	}
+}
@@ -0,0 +1,96 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHelpVersion(t *testing.T) {
+	cmd := NewSimpleCommand("app", "an app")
+	cmdRemote := cmd.NewSimpleSubCommand("remote", "manage remotes")
+	if err := cmd.EnableHelp(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.EnableVersion("1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+	cmdAdd := cmdRemote.NewSimpleSubCommand("add", "add a remote")
+	cmdAdd.options.AddPositional(Positional{Name: "name", Required: true})
+
+	result, err := cmd.ParseStrict([]string{"app", "remote", "add", "-h"}, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if !result.HelpRequested() || result.VersionRequested() {
+		t.Error("help")
+	}
+	if result.Command() != cmdAdd {
+		t.Error(result.Command().Name())
+	}
+
+	buffer := &bytes.Buffer{}
+	result.OutputHelp(buffer)
+	if !strings.HasPrefix(buffer.String(), "add: add a remote") || !strings.Contains(buffer.String(), "-h|--help") {
+		t.Error(buffer.String())
+	}
+
+	result = cmd.Parse([]string{"app", "remote", "--version"}, nil)
+	if !result.VersionRequested() {
+		t.Error("version")
+	}
+	buffer.Reset()
+	result.OutputVersion(buffer)
+	if buffer.String() != "1.2.3\n" {
+		t.Error(buffer.String())
+	}
+
+	if _, err = cmd.ParseStrict([]string{"app", "remote", "add"}, nil); err == nil {
+		t.Error("should report missing positional")
+	}
+
+	if err = getGitCommand().EnableHelp(); err == nil {
+		t.Error("should fail for existing help key")
+	}
+}
+
+func TestEnableHelpConflict(t *testing.T) {
+	cmd := NewSimpleCommand("app", "")
+	cmdRemote := cmd.NewSimpleSubCommand("remote", "")
+	cmdRemote.options.AddFlag("host", "-h", "", "")
+	cmdReset := cmd.NewSimpleSubCommand("reset", "")
+
+	err := cmd.EnableHelp()
+	if err == nil || err.Error() != "command 'remote': flag '-h' already exists" {
+		t.Error(err)
+	}
+	for _, c := range []*Command{cmd, cmdRemote, cmdReset} {
+		if c.helpOption != nil || c.options.keyOptionMap[HelpKey] != nil {
+			t.Error(c.Name())
+		}
+	}
+
+	if err = cmd.EnableHelp("--help"); err != nil {
+		t.Fatal(err)
+	}
+
+	cmdExtra := NewSimpleCommand("extra", "")
+	cmdExtra.options.AddFlag("helper", "--help", "", "")
+	if err = cmd.AddSubCommand(cmdExtra); err == nil {
+		t.Error("should fail for conflict help flag")
+	}
+	if len(cmd.subCommands) != 2 || cmdExtra.parent != nil {
+		t.Error(cmd.subCommands)
+	}
+
+	cmdExtra = NewSimpleCommand("extra", "")
+	if err = cmd.AddSubCommand(cmdExtra); err != nil {
+		t.Error(err)
+	}
+	if cmdExtra.helpOption == nil || cmd.GetSubCommand("extra") != cmdExtra {
+		t.Error("extra")
+	}
+	if err = cmdRemote.AddSubCommand(cmdExtra); err == nil {
+		t.Error("should fail for command with parent")
+	}
+}
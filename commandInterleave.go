@@ -0,0 +1,166 @@
+package goNixArgParser
+
+type cmdLevel struct {
+	cmd    *Command
+	args   []string
+	offset int
+}
+
+func (c *Command) SetInterleaved(interleaved bool) {
+	c.interleaved = interleaved
+}
+
+func (c *Command) Interleaved() bool {
+	return c.interleaved
+}
+
+func (c *Command) findInterleavedSubCommand(args []string, offset int) (subCmd *Command, index int) {
+	if len(c.subCommands) == 0 || len(args) == 0 {
+		return nil, -1
+	}
+
+	s := c.effectiveOptions()
+	tokens := s.argsToTokensGroups(args, offset)[0]
+	s.parseTokensInGroup(tokens)
+
+	for _, token := range tokens {
+		switch token.kind {
+		case restSignArg:
+			return nil, -1
+		case restArg:
+			if subCmd = c.GetSubCommand(token.text); subCmd != nil {
+				return subCmd, token.index
+			}
+			return nil, -1
+		}
+	}
+
+	return nil, -1
+}
+
+func (c *Command) getInterleavedLevels(args []string) (levels []*cmdLevel, cmdPaths []string, explicit bool) {
+	cmd := c
+	start := 0
+	cmdPaths = []string{}
+
+	if len(args) > 0 && c.hasName(args[0]) {
+		explicit = true
+		cmdPaths = append(cmdPaths, c.Name())
+		start = 1
+	}
+
+	for {
+		subCmd, index := cmd.findInterleavedSubCommand(args[start:], start)
+		if subCmd == nil {
+			break
+		}
+
+		levels = append(levels, &cmdLevel{cmd: cmd, args: args[start:index], offset: start})
+		explicit = true
+		cmdPaths = append(cmdPaths, subCmd.Name())
+		cmd = subCmd
+		start = index + 1
+	}
+
+	levels = append(levels, &cmdLevel{cmd: cmd, args: args[start:], offset: start})
+	return
+}
+
+func (r *ParseResult) getMergeableOption(key string) *Option {
+	opt := r.keyOptionMap[key]
+	if opt == nil || !(opt.Persistent || r.isBuiltinKey(key)) {
+		return nil
+	}
+	return opt
+}
+
+func mergeLevelResult(result, levelResult *ParseResult) {
+	for key, values := range levelResult.specifiedOptions {
+		opt := result.getMergeableOption(key)
+		if opt == nil || result.HasFlagKey(key) {
+			continue
+		}
+		result.specifiedOptions[key] = values
+		result.specifiedFlags[key] = levelResult.specifiedFlags[key]
+		result.parseValue(opt)
+	}
+
+	for key, values := range levelResult.configOptions {
+		opt := result.getMergeableOption(key)
+		if opt == nil || result.HasConfigKey(key) {
+			continue
+		}
+		result.configOptions[key] = values
+		result.configFlags[key] = levelResult.configFlags[key]
+		result.parseValue(opt)
+	}
+}
+
+func (c *Command) parseInterleaved(specifiedArgs, configArgs []string, groups bool) []*ParseResult {
+	specifiedLevels, cmdPaths, _ := c.getInterleavedLevels(specifiedArgs)
+	configLevels, _, configExplicit := c.getInterleavedLevels(configArgs)
+
+	leafLevel := specifiedLevels[len(specifiedLevels)-1]
+	leafCmd := leafLevel.cmd
+
+	cmdConfigLevels := map[*Command]*cmdLevel{}
+	if configExplicit {
+		for _, level := range configLevels {
+			cmdConfigLevels[level.cmd] = level
+		}
+	} else {
+		cmdConfigLevels[leafCmd] = configLevels[len(configLevels)-1]
+	}
+
+	levelResults := make([]*ParseResult, 0, len(specifiedLevels))
+	for _, level := range specifiedLevels[:len(specifiedLevels)-1] {
+		var configLevelArgs []string
+		configOffset := 0
+		if configLevel := cmdConfigLevels[level.cmd]; configLevel != nil {
+			configLevelArgs = configLevel.args
+			configOffset = configLevel.offset
+		}
+
+		levelResult := level.cmd.effectiveOptions().parseAt(level.args, configLevelArgs, level.offset, configOffset)
+		levelResult.commands = cmdPaths
+		levelResult.command = level.cmd
+		levelResults = append(levelResults, levelResult)
+	}
+
+	var configLeafArgs []string
+	configLeafOffset := 0
+	if configLevel := cmdConfigLevels[leafCmd]; configLevel != nil {
+		configLeafArgs = configLevel.args
+		configLeafOffset = configLevel.offset
+	}
+
+	results := leafCmd.parseOptionArgs(leafLevel.args, configLeafArgs, leafLevel.offset, configLeafOffset, groups)
+	leafCmd.fillResults(results, cmdPaths, leafLevel.args, leafLevel.offset)
+
+	for _, result := range results {
+		for i := len(levelResults) - 1; i >= 0; i-- {
+			mergeLevelResult(result, levelResults[i])
+		}
+		result.levelResults = append(append(make([]*ParseResult, 0, len(levelResults)+1), levelResults...), result)
+	}
+
+	var levelErrors []*ParseError
+	for _, levelResult := range levelResults {
+		levelErrors = append(levelErrors, levelResult.parseErrors...)
+		results[0].specifiedAmbigus = append(results[0].specifiedAmbigus, levelResult.specifiedAmbigus...)
+		results[0].specifiedUndefs = append(results[0].specifiedUndefs, levelResult.specifiedUndefs...)
+	}
+	results[0].parseErrors = append(levelErrors, results[0].parseErrors...)
+
+	return results
+}
+
+func (r *ParseResult) GetLevelResults() []*ParseResult {
+	if len(r.levelResults) == 0 {
+		return []*ParseResult{r}
+	}
+
+	results := make([]*ParseResult, len(r.levelResults))
+	copy(results, r.levelResults)
+	return results
+}
@@ -0,0 +1,135 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func getInterleavedCommand() *Command {
+	cmd := NewSimpleCommand("app", "")
+	cmd.SetInterleaved(true)
+	cmd.options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"-v", "--verbose"}), Persistent: true})
+	cmd.options.AddFlagValue("config", "--config", "", "", "")
+
+	cmdRemote := cmd.NewSimpleSubCommand("remote", "")
+	cmdRemote.options.AddFlagValue("timeout", "--timeout", "", "", "")
+
+	cmdAdd := cmdRemote.NewSimpleSubCommand("add", "")
+	cmdAdd.options.AddFlag("fetch", "-f", "", "")
+
+	return cmd
+}
+
+func TestParseInterleaved(t *testing.T) {
+	cmd := getInterleavedCommand()
+
+	args := []string{"app", "--verbose", "--config", "remote", "remote", "--timeout=3", "add", "-f", "origin"}
+	result := cmd.Parse(args, nil)
+
+	if !expectStrings(result.GetCommands(), "app", "remote", "add") {
+		t.Fatal(result.GetCommands())
+	}
+	if !result.HasFlagKey("fetch") || !result.HasFlagKey("verbose") {
+		t.Error("fetch and verbose should be in leaf result")
+	}
+	if !expectStrings(result.GetRests(), "origin") {
+		t.Error(result.GetRests())
+	}
+
+	levels := result.GetLevelResults()
+	if len(levels) != 3 || levels[2] != result {
+		t.Fatal(levels)
+	}
+	if config, _ := levels[0].GetString("config"); config != "remote" {
+		t.Error(config)
+	}
+	if timeout, _ := levels[1].GetString("timeout"); timeout != "3" {
+		t.Error(timeout)
+	}
+	if levels[1].Command().Name() != "remote" {
+		t.Error(levels[1].Command().Name())
+	}
+
+	configArgs := []string{"remote", "--timeout", "5", "add", "-f"}
+	result = cmd.Parse([]string{"app", "-x", "remote", "add"}, configArgs)
+	if !result.HasConfigKey("fetch") {
+		t.Error("config fetch")
+	}
+	if timeout, _ := result.GetLevelResults()[1].GetString("timeout"); timeout != "5" {
+		t.Error(timeout)
+	}
+	parseErrors := result.GetParseErrors()
+	if len(parseErrors) != 1 || parseErrors[0].Arg != "-x" || parseErrors[0].Index != 1 {
+		t.Error(parseErrors)
+	}
+
+	results := cmd.ParseGroups([]string{"app", "-v", "remote", "add", "-f", ",,", "upstream"}, nil)
+	if len(results) != 2 || !results[1].HasFlagKey("verbose") || results[1].HasFlagKey("fetch") {
+		t.Error(results)
+	}
+	if results[0].GetLevelResults()[2] != results[0] || results[1].GetLevelResults()[2] != results[1] {
+		t.Error("level results of groups")
+	}
+
+	cmd.SetInterleaved(false)
+	result = cmd.Parse(args, nil)
+	if !expectStrings(result.GetCommands(), "app") {
+		t.Error(result.GetCommands())
+	}
+}
+
+func TestExecuteInterleavedHelpVersion(t *testing.T) {
+	cmd := getInterleavedCommand()
+	cmd.EnableHelp()
+	cmd.EnableVersion("1.2.3")
+	buffer := &bytes.Buffer{}
+	cmd.SetOutput(buffer)
+
+	handled := false
+	cmdAdd := cmd.GetSubCommand("remote").GetSubCommand("add")
+	cmdAdd.SetHandler(func(ctx context.Context, result *ParseResult) error {
+		handled = true
+		return nil
+	})
+
+	if err := cmd.Execute([]string{"app", "--help", "remote", "add"}); err != nil {
+		t.Error(err)
+	}
+	if handled || !strings.HasPrefix(buffer.String(), "add: Usage:") {
+		t.Error(handled, buffer.String())
+	}
+
+	buffer.Reset()
+	if err := cmd.Execute([]string{"app", "--version", "remote", "add"}); err != nil {
+		t.Error(err)
+	}
+	if handled || buffer.String() != "1.2.3\n" {
+		t.Error(handled, buffer.String())
+	}
+
+	if err := cmd.Execute([]string{"app", "remote", "add"}); err != nil || !handled {
+		t.Error(err, handled)
+	}
+}
+
+func TestParseInterleavedConfigPersistent(t *testing.T) {
+	cmd := getInterleavedCommand()
+
+	result := cmd.Parse([]string{"app", "remote", "add"}, []string{"app", "-v", "remote", "add"})
+	if !result.HasKey("verbose") || !result.HasConfigKey("verbose") || result.HasFlagKey("verbose") {
+		t.Error("verbose should be merged from config")
+	}
+	if p := result.GetProvenance("verbose"); p.Source != ConfigSource || p.Flag != "-v" {
+		t.Error(p)
+	}
+	if result.HasKey("config") {
+		t.Error("non persistent option should not be merged")
+	}
+
+	result = cmd.Parse([]string{"app", "--verbose", "remote", "add"}, []string{"app", "-v", "remote", "add"})
+	if p := result.GetProvenance("verbose"); p.Source != FlagSource || p.Flag != "--verbose" || len(p.Shadowed) != 1 || p.Shadowed[0].Flag != "-v" {
+		t.Error(p)
+	}
+}
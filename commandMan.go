@@ -0,0 +1,172 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var manEscapeReplacer = strings.NewReplacer(`\`, `\e`, `-`, `\-`)
+
+func manEscape(input string) string {
+	return manEscapeReplacer.Replace(input)
+}
+
+func manText(input string) string {
+	lines := strings.Split(strings.TrimSpace(input), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case len(line) == 0:
+			lines[i] = ".sp"
+		case line[0] == '.' || line[0] == '\'':
+			lines[i] = `\&` + manEscape(line)
+		default:
+			lines[i] = manEscape(line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (c *Command) ManPageName() string {
+	return c.docName()
+}
+
+func (c *Command) manPageRef(section string) string {
+	return ".BR " + manEscape(c.ManPageName()) + " (" + section + ")"
+}
+
+func (opt *Option) outputManItem(w io.Writer) {
+	io.WriteString(w, ".TP\n")
+	for i, name := range opt.flagNames() {
+		if i > 0 {
+			io.WriteString(w, ", ")
+		}
+		io.WriteString(w, `\fB`+manEscape(name)+`\fR`)
+	}
+	if opt.AcceptValue {
+		io.WriteString(w, ` \fI`+manEscape(opt.valueUsage())+`\fR`)
+	}
+	if opt.Required {
+		io.WriteString(w, " (required)")
+	}
+	io.WriteString(w, "\n")
+
+	if len(opt.Summary) > 0 {
+		io.WriteString(w, manText(opt.Summary))
+	}
+	if len(opt.Description) > 0 {
+		if len(opt.Summary) > 0 {
+			io.WriteString(w, ".br\n")
+		}
+		io.WriteString(w, manText(opt.Description))
+	}
+	if len(opt.EnvVars) > 0 {
+		io.WriteString(w, ".br\nEnvironment: "+manEscape(strings.Join(opt.EnvVars, ", "))+"\n")
+	}
+	if len(opt.DefaultValues) > 0 {
+		io.WriteString(w, ".br\nDefault: "+manEscape(strings.Join(opt.DefaultValues, ", "))+"\n")
+	}
+}
+
+func outputManOptions(w io.Writer, title string, options []*Option) {
+	visibleOptions := []*Option{}
+	for _, opt := range options {
+		if !opt.Hidden {
+			visibleOptions = append(visibleOptions, opt)
+		}
+	}
+	if len(visibleOptions) == 0 {
+		return
+	}
+
+	io.WriteString(w, ".SH "+title+"\n")
+	for _, opt := range visibleOptions {
+		opt.outputManItem(w)
+	}
+}
+
+func (c *Command) OutputManPage(w io.Writer, section string) {
+	cmdPaths := c.docPaths()
+	pageName := manEscape(c.ManPageName())
+
+	source := cmdPaths[0]
+	if len(c.version) > 0 {
+		source += " " + c.version
+	}
+	io.WriteString(w, `.TH "`+manEscape(strings.ToUpper(c.ManPageName()))+`" "`+section+`" "" "`+manEscape(source)+`" ""`+"\n")
+
+	// name
+	io.WriteString(w, ".SH NAME\n")
+	io.WriteString(w, pageName)
+	if len(c.summary) > 0 {
+		io.WriteString(w, ` \- `+manEscape(c.summary))
+	}
+	io.WriteString(w, "\n")
+
+	// synopsis
+	io.WriteString(w, ".SH SYNOPSIS\n")
+	io.WriteString(w, `\fB`+manEscape(strings.Join(cmdPaths, " "))+`\fR `+manEscape(c.usageArgs())+"\n")
+
+	// options
+	outputManOptions(w, "OPTIONS", c.options.options)
+	outputManOptions(w, "GLOBAL OPTIONS", c.globalOptions())
+
+	// arguments
+	if len(c.options.positionals) > 0 {
+		io.WriteString(w, ".SH ARGUMENTS\n")
+		for _, p := range c.options.positionals {
+			io.WriteString(w, ".TP\n"+`\fB`+manEscape(p.usage())+`\fR`+"\n")
+			if len(p.Summary) > 0 {
+				io.WriteString(w, manText(p.Summary))
+			}
+		}
+	}
+
+	// sub commands
+	if len(c.subCommands) > 0 {
+		io.WriteString(w, ".SH COMMANDS\n")
+		for _, subCmd := range c.subCommands {
+			io.WriteString(w, ".TP\n"+`\fB`+manEscape(strings.Join(subCmd.names, ", "))+`\fR`+"\n")
+			if len(subCmd.summary) > 0 {
+				io.WriteString(w, manText(subCmd.summary))
+			}
+		}
+	}
+
+	// see also
+	related := []*Command{}
+	if c.parent != nil {
+		related = append(related, c.parent)
+	}
+	related = append(related, c.subCommands...)
+	if len(related) > 0 {
+		io.WriteString(w, ".SH SEE ALSO\n")
+		for i, cmd := range related {
+			io.WriteString(w, cmd.manPageRef(section))
+			if i < len(related)-1 {
+				io.WriteString(w, ",")
+			}
+			io.WriteString(w, "\n")
+		}
+	}
+}
+
+func (c *Command) WriteManPages(dir, section string) error {
+	var err error
+
+	c.walk(nil, func(cmd *Command, _ []string) {
+		if err != nil {
+			return
+		}
+
+		buffer := &bytes.Buffer{}
+		cmd.OutputManPage(buffer, section)
+		filename := filepath.Join(dir, cmd.ManPageName()+"."+section)
+		err = os.WriteFile(filename, buffer.Bytes(), 0644)
+	})
+
+	return err
+}
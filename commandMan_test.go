@@ -0,0 +1,64 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutputManPage(t *testing.T) {
+	cmd := getGitCommand()
+	cmdSetUrl := cmd.GetSubCommand("remote").GetSubCommand("set-url")
+	cmdSetUrl.options.Add(Option{
+		Key:           "timeout",
+		Flags:         NewSimpleFlags([]string{"--timeout", "-t"}),
+		AcceptValue:   true,
+		EnvVars:       []string{"GIT_TIMEOUT"},
+		DefaultValues: []string{"30s"},
+		Summary:       "network timeout",
+		Description:   ".5s at least\nuse C:\\ path",
+	})
+	cmdSetUrl.options.AddPositional(Positional{Name: "name", Required: true, Summary: "remote name"})
+
+	buffer := &bytes.Buffer{}
+	cmdSetUrl.OutputManPage(buffer, "1")
+	page := buffer.String()
+
+	expects := []string{
+		".TH \"GIT\\-REMOTE\\-SET\\-URL\" \"1\" \"\" \"git\" \"\"\n",
+		".SH NAME\ngit\\-remote\\-set\\-url \\- set remote url\n",
+		".SH SYNOPSIS\n\\fBgit remote set\\-url\\fR [options] <name>\n",
+		".TP\n\\fB\\-\\-timeout\\fR, \\fB\\-t\\fR \\fI<value>\\fR\nnetwork timeout\n.br\n\\&.5s at least\nuse C:\\e path\n" +
+			".br\nEnvironment: GIT_TIMEOUT\n.br\nDefault: 30s\n",
+		".SH ARGUMENTS\n.TP\n\\fB<name>\\fR\nremote name\n",
+		".SH SEE ALSO\n.BR git\\-remote (1)\n",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(page, expect) {
+			t.Error(expect)
+		}
+	}
+
+	buffer.Reset()
+	cmd.OutputManPage(buffer, "1")
+	page = buffer.String()
+	if !strings.Contains(page, ".SH COMMANDS\n.TP\n\\fBremote, rmt, rt\\fR\nmanage remotes\n") ||
+		!strings.Contains(page, ".SH SEE ALSO\n.BR git\\-remote (1),\n.BR git\\-reset (1)\n") {
+		t.Error(page)
+	}
+}
+
+func TestWriteManPages(t *testing.T) {
+	dir := t.TempDir()
+	if err := getGitCommand().WriteManPages(dir, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"git.1", "git-remote.1", "git-remote-set-url.1", "git-reset.1"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+var markdownCellReplacer = strings.NewReplacer(`|`, `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func markdownCell(input string) string {
+	return markdownCellReplacer.Replace(strings.TrimSpace(input))
+}
+
+func markdownCodes(items []string) string {
+	codes := make([]string, len(items))
+	for i, item := range items {
+		codes[i] = "`" + markdownCell(item) + "`"
+	}
+	return strings.Join(codes, ", ")
+}
+
+func markdownAnchor(title string) string {
+	anchor := &strings.Builder{}
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ':
+			anchor.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			anchor.WriteRune(r)
+		}
+	}
+	return anchor.String()
+}
+
+func (c *Command) markdownTitle() string {
+	return strings.Join(c.docPaths(), " ")
+}
+
+func (c *Command) markdownAnchorLink() string {
+	return "#" + markdownAnchor(c.markdownTitle())
+}
+
+func (c *Command) markdownFileLink() string {
+	return c.docName() + ".md"
+}
+
+func outputMarkdownOptions(w io.Writer, heading, title string, options []*Option) {
+	visibleOptions := []*Option{}
+	for _, opt := range options {
+		if !opt.Hidden {
+			visibleOptions = append(visibleOptions, opt)
+		}
+	}
+	if len(visibleOptions) == 0 {
+		return
+	}
+
+	io.WriteString(w, heading+" "+title+"\n\n")
+	io.WriteString(w, "| Flags | Value | Env vars | Defaults | Description |\n")
+	io.WriteString(w, "| ----- | ----- | -------- | -------- | ----------- |\n")
+	for _, opt := range visibleOptions {
+		value := ""
+		if opt.AcceptValue {
+			value = "`" + opt.valueUsage() + "`"
+		}
+
+		descriptions := []string{}
+		if opt.Required {
+			descriptions = append(descriptions, "(required)")
+		}
+		if len(opt.Summary) > 0 {
+			descriptions = append(descriptions, markdownCell(opt.Summary))
+		}
+		if len(opt.Description) > 0 {
+			descriptions = append(descriptions, markdownCell(opt.Description))
+		}
+
+		io.WriteString(w, "| "+markdownCodes(opt.flagNames())+
+			" | "+value+
+			" | "+markdownCodes(opt.EnvVars)+
+			" | "+markdownCodes(opt.DefaultValues)+
+			" | "+strings.Join(descriptions, "<br>")+
+			" |\n")
+	}
+	io.WriteString(w, "\n")
+}
+
+func (c *Command) outputMarkdown(w io.Writer, level int, link func(cmd *Command) string) {
+	heading := strings.Repeat("#", level)
+	subHeading := heading + "#"
+
+	io.WriteString(w, heading+" "+c.markdownTitle()+"\n\n")
+	if len(c.summary) > 0 {
+		io.WriteString(w, c.summary+"\n\n")
+	}
+	if c.parent != nil {
+		io.WriteString(w, "Parent command: ["+c.parent.markdownTitle()+"]("+link(c.parent)+")\n\n")
+	}
+
+	// usage
+	io.WriteString(w, subHeading+" Usage\n\n")
+	io.WriteString(w, "```\n"+strings.Join(c.docPaths(), " ")+" "+c.usageArgs()+"\n```\n\n")
+
+	// options
+	outputMarkdownOptions(w, subHeading, "Options", c.options.options)
+	outputMarkdownOptions(w, subHeading, "Global options", c.globalOptions())
+
+	// arguments
+	if len(c.options.positionals) > 0 {
+		io.WriteString(w, subHeading+" Arguments\n\n")
+		io.WriteString(w, "| Name | Description |\n")
+		io.WriteString(w, "| ---- | ----------- |\n")
+		for _, p := range c.options.positionals {
+			io.WriteString(w, "| `"+p.usage()+"` | "+markdownCell(p.Summary)+" |\n")
+		}
+		io.WriteString(w, "\n")
+	}
+
+	// sub commands
+	if len(c.subCommands) > 0 {
+		io.WriteString(w, subHeading+" Sub commands\n\n")
+		io.WriteString(w, "| Command | Aliases | Description |\n")
+		io.WriteString(w, "| ------- | ------- | ----------- |\n")
+		for _, subCmd := range c.subCommands {
+			io.WriteString(w, "| ["+subCmd.Name()+"]("+link(subCmd)+")"+
+				" | "+markdownCodes(subCmd.names[1:])+
+				" | "+markdownCell(subCmd.summary)+
+				" |\n")
+		}
+		io.WriteString(w, "\n")
+	}
+}
+
+func (c *Command) OutputMarkdown(w io.Writer) {
+	c.walk(nil, func(cmd *Command, _ []string) {
+		cmd.outputMarkdown(w, 2, (*Command).markdownAnchorLink)
+	})
+}
+
+func (c *Command) WriteMarkdownFiles(dir string) error {
+	var err error
+
+	c.walk(nil, func(cmd *Command, _ []string) {
+		if err != nil {
+			return
+		}
+
+		buffer := &bytes.Buffer{}
+		cmd.outputMarkdown(buffer, 1, (*Command).markdownFileLink)
+		err = os.WriteFile(filepath.Join(dir, cmd.markdownFileLink()), buffer.Bytes(), 0644)
+	})
+
+	return err
+}
@@ -0,0 +1,66 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutputMarkdown(t *testing.T) {
+	cmd := getGitCommand()
+	cmdSetUrl := cmd.GetSubCommand("remote").GetSubCommand("set-url")
+	cmdSetUrl.options.Add(Option{
+		Key:           "timeout",
+		Flags:         NewSimpleFlags([]string{"--timeout", "-t"}),
+		AcceptValue:   true,
+		EnvVars:       []string{"GIT_TIMEOUT"},
+		DefaultValues: []string{"30s"},
+		Summary:       "network timeout",
+		Description:   "a | b",
+	})
+	cmdSetUrl.options.AddPositional(Positional{Name: "name", Required: true, Summary: "remote name"})
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputMarkdown(buffer)
+	doc := buffer.String()
+
+	expects := []string{
+		"## git\n\nA version control tool\n\n### Usage\n\n```\ngit [options] [command]\n```\n",
+		"| [remote](#git-remote) | `rmt`, `rt` | manage remotes |\n",
+		"## git remote set-url\n\nset remote url\n\nParent command: [git remote](#git-remote)\n",
+		"```\ngit remote set-url [options] <name>\n```\n",
+		"| `--timeout`, `-t` | `<value>` | `GIT_TIMEOUT` | `30s` | network timeout<br>a \\| b |\n",
+		"### Arguments\n\n| Name | Description |\n| ---- | ----------- |\n| `<name>` | remote name |\n",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(doc, expect) {
+			t.Error(expect)
+		}
+	}
+}
+
+func TestWriteMarkdownFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := getGitCommand().WriteMarkdownFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "git-remote.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(content)
+	if !strings.HasPrefix(doc, "# git remote\n") ||
+		!strings.Contains(doc, "Parent command: [git](git.md)\n") ||
+		!strings.Contains(doc, "| [set-url](git-remote-set-url.md) |") {
+		t.Error(doc)
+	}
+
+	for _, name := range []string{"git.md", "git-remote-set-url.md", "git-reset.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
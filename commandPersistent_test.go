@@ -0,0 +1,72 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPersistentOptions(t *testing.T) {
+	cmd := NewSimpleCommand("app", "")
+	cmd.options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"-v", "--verbose"}), Summary: "verbose output", Persistent: true})
+	cmd.options.Add(Option{Key: "config", Flags: NewSimpleFlags([]string{"--config"}), AcceptValue: true, Persistent: true})
+	cmd.options.AddFlag("local", "--local", "", "")
+
+	cmdRemote := cmd.NewSimpleSubCommand("remote", "")
+	cmdAdd := cmdRemote.NewSimpleSubCommand("add", "")
+	cmdAdd.options.AddFlag("fetch", "-f", "", "")
+	cmdAdd.options.AddFlagValue("config", "--config", "", "", "add config")
+
+	result := cmd.Parse([]string{"app", "remote", "add", "-vf", "--config", "a.conf", "--local"}, nil)
+	if !result.HasFlagKey("verbose") || !result.HasFlagKey("fetch") {
+		t.Error("verbose and fetch should be parsed")
+	}
+	if config, _ := result.GetString("config"); config != "a.conf" {
+		t.Error(config)
+	}
+	if result.HasFlagKey("local") || !expectStrings(result.GetUndefs(), "--local") {
+		t.Error(result.GetUndefs())
+	}
+
+	buffer := &bytes.Buffer{}
+	cmdAdd.OutputHelp(buffer)
+	help := buffer.String()
+	if !strings.Contains(help, "Global options:\n\n-v|--verbose\nverbose output\n") {
+		t.Error(help)
+	}
+	if strings.Count(help, "--config") != 1 {
+		t.Error(help)
+	}
+}
+
+func TestPersistentConstraints(t *testing.T) {
+	cmd := NewSimpleCommand("app", "")
+	cmd.options.Add(Option{Key: "json", Flags: NewSimpleFlags([]string{"--json"}), Persistent: true})
+	cmd.options.Add(Option{Key: "yaml", Flags: NewSimpleFlags([]string{"--yaml"}), Persistent: true})
+	cmd.options.AddFlag("local", "--local", "", "")
+	cmd.options.AddExclusive("json", "yaml")
+	cmd.options.AddConflicts("local", "json")
+
+	cmd.NewSimpleSubCommand("list", "")
+	cmdShow := cmd.NewSimpleSubCommand("show", "")
+	cmdShow.options.AddFlag("yaml", "--yaml", "", "")
+
+	if _, err := cmd.ParseStrict([]string{"app", "--json", "--yaml"}, nil); err == nil {
+		t.Error("should violate exclusive constraint")
+	}
+
+	_, err := cmd.ParseStrict([]string{"app", "list", "--json", "--yaml"}, nil)
+	var violation *ConstraintViolation
+	if !errors.As(err, &violation) || violation.Kind != ExclusiveConstraint {
+		t.Error(err)
+	}
+
+	if _, err = cmd.ParseStrict([]string{"app", "show", "--json", "--yaml"}, nil); err != nil {
+		t.Error(err)
+	}
+
+	if n := len(cmd.options.constraints); n != 2 {
+		t.Error(n)
+	}
+}
@@ -0,0 +1,130 @@
+package goNixArgParser
+
+import (
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var reNonIdentifier = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func toIdentifier(input string) string {
+	return reNonIdentifier.ReplaceAllString(input, "_")
+}
+
+func shellQuote(input string) string {
+	return "'" + strings.ReplaceAll(input, "'", `'\''`) + "'"
+}
+
+func (c *Command) programName() string {
+	return path.Base(c.Name())
+}
+
+func (s *OptionSet) completionFlags() (flags, valueFlags []string) {
+	for _, opt := range s.options {
+		if opt.Hidden {
+			continue
+		}
+		for _, flag := range opt.Flags {
+			flags = append(flags, flag.Name)
+			if opt.AcceptValue {
+				valueFlags = append(valueFlags, flag.Name)
+			}
+		}
+	}
+	return
+}
+
+func dynamicFlag(dynamic bool) string {
+	if dynamic {
+		return "1"
+	}
+	return ""
+}
+
+func (c *Command) OutputBashCompletion(w io.Writer) {
+	program := c.programName()
+	funcName := "_" + toIdentifier(program) + "_completion"
+	dynamic := c.hasDynamicCompletion()
+
+	io.WriteString(w, "# bash completion for "+program+"\n\n")
+
+	// path info
+	io.WriteString(w, funcName+"_path_info() {\n")
+	io.WriteString(w, "\tcase \"$1\" in\n")
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		flags, valueFlags := cmd.effectiveOptions().completionFlags()
+
+		io.WriteString(w, "\t"+shellQuote(strings.Join(cmdPaths, " "))+")\n")
+		io.WriteString(w, "\t\tcommands="+shellQuote(strings.Join(cmd.subCommandNames(), " "))+"\n")
+		io.WriteString(w, "\t\tflags="+shellQuote(strings.Join(flags, " "))+"\n")
+		io.WriteString(w, "\t\tvalue_flags="+shellQuote(strings.Join(valueFlags, " "))+"\n")
+		if dynamic {
+			io.WriteString(w, "\t\tdynamic="+shellQuote(dynamicFlag(cmd.effectiveOptions().hasDynamicCompletion()))+"\n")
+		}
+		io.WriteString(w, "\t\t;;\n")
+	})
+	io.WriteString(w, "\t*)\n\t\tcommands=''\n\t\tflags=''\n\t\tvalue_flags=''\n")
+	if dynamic {
+		io.WriteString(w, "\t\tdynamic=''\n")
+	}
+	io.WriteString(w, "\t\t;;\n")
+	io.WriteString(w, "\tesac\n}\n\n")
+
+	// completion
+	io.WriteString(w, funcName+"() {\n")
+	io.WriteString(w, "\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	io.WriteString(w, "\tlocal prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	io.WriteString(w, "\tlocal path="+shellQuote(program)+"\n")
+	if dynamic {
+		io.WriteString(w, "\tlocal commands flags value_flags dynamic word i\n\n")
+	} else {
+		io.WriteString(w, "\tlocal commands flags value_flags word i\n\n")
+	}
+
+	io.WriteString(w, "\tfor ((i = 1; i < COMP_CWORD; i++)); do\n")
+	io.WriteString(w, "\t\tword=\"${COMP_WORDS[i]}\"\n")
+	io.WriteString(w, "\t\t"+funcName+"_path_info \"$path\"\n")
+	io.WriteString(w, "\t\tif [[ \" $value_flags \" == *\" $word \"* ]]; then\n")
+	io.WriteString(w, "\t\t\t((i++))\n")
+	io.WriteString(w, "\t\t\tcontinue\n")
+	io.WriteString(w, "\t\tfi\n")
+	io.WriteString(w, "\t\tcase \"$path/$word\" in\n")
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		parentPath := strings.Join(cmdPaths, " ")
+		for _, subCmd := range cmd.subCommands {
+			patterns := make([]string, len(subCmd.names))
+			for i, name := range subCmd.names {
+				patterns[i] = shellQuote(parentPath + "/" + name)
+			}
+			io.WriteString(w, "\t\t"+strings.Join(patterns, "|")+")\n")
+			io.WriteString(w, "\t\t\tpath="+shellQuote(parentPath+" "+subCmd.Name())+"\n")
+			io.WriteString(w, "\t\t\t;;\n")
+		}
+	})
+	io.WriteString(w, "\t\tesac\n")
+	io.WriteString(w, "\tdone\n\n")
+
+	io.WriteString(w, "\t"+funcName+"_path_info \"$path\"\n")
+	if dynamic {
+		io.WriteString(w, "\tif [[ -n \"$dynamic\" && \"$cur\" != -* ]]; then\n")
+		io.WriteString(w, "\t\tmapfile -t COMPREPLY < <(\"${COMP_WORDS[0]}\" "+CompleteCommandName+" \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null)\n")
+		io.WriteString(w, "\t\tif [[ ${#COMPREPLY[@]} -gt 0 ]]; then\n")
+		io.WriteString(w, "\t\t\treturn\n")
+		io.WriteString(w, "\t\tfi\n")
+		io.WriteString(w, "\tfi\n")
+	}
+	io.WriteString(w, "\tif [[ \" $value_flags \" == *\" $prev \"* ]]; then\n")
+	io.WriteString(w, "\t\tCOMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	io.WriteString(w, "\telif [[ \"$cur\" == -* ]]; then\n")
+	io.WriteString(w, "\t\tCOMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))\n")
+	io.WriteString(w, "\telse\n")
+	io.WriteString(w, "\t\tCOMPREPLY=($(compgen -W \"$commands\" -- \"$cur\"))\n")
+	io.WriteString(w, "\tfi\n")
+	io.WriteString(w, "}\n\n")
+
+	io.WriteString(w, "complete -o default -F "+funcName+" "+program+"\n")
+}
@@ -0,0 +1,34 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputBashCompletion(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.Add(Option{Key: "secret", Flags: NewSimpleFlags([]string{"--secret"}), Hidden: true})
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputBashCompletion(buffer)
+	script := buffer.String()
+
+	expects := []string{
+		"_git_completion() {",
+		"\t'git remote set-url')\n\t\tcommands=''\n\t\tflags='--push --dummy --dummy-x'\n\t\tvalue_flags='--dummy --dummy-x'\n",
+		"\t'git')\n\t\tcommands='remote rmt rt reset'\n",
+		"\t\t'git/remote'|'git/rmt'|'git/rt')\n\t\t\tpath='git remote'\n",
+		"\t\t'git remote/set-url')\n\t\t\tpath='git remote set-url'\n",
+		"complete -o default -F _git_completion git\n",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(script, expect) {
+			t.Error(expect)
+		}
+	}
+
+	if strings.Contains(script, "--secret") {
+		t.Error("hidden flag should not be completed")
+	}
+}
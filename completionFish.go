@@ -0,0 +1,159 @@
+package goNixArgParser
+
+import (
+	"io"
+	"strings"
+)
+
+var fishQuoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func fishQuote(input string) string {
+	return "'" + fishQuoteReplacer.Replace(input) + "'"
+}
+
+func fishFlagArgs(flagName string) string {
+	switch {
+	case strings.HasPrefix(flagName, "--") && len(flagName) > 2:
+		return "-l " + fishQuote(flagName[2:])
+	case strings.HasPrefix(flagName, "-") && len(flagName) == 2:
+		return "-s " + fishQuote(flagName[1:])
+	case strings.HasPrefix(flagName, "-") && len(flagName) > 2:
+		return "-o " + fishQuote(flagName[1:])
+	default:
+		return "-a " + fishQuote(flagName)
+	}
+}
+
+func (c *Command) OutputFishCompletion(w io.Writer) {
+	program := c.programName()
+	prefix := "__" + toIdentifier(program)
+	pathFunc := prefix + "_complete_path"
+	valueFlagsFunc := prefix + "_value_flags"
+	pathIsFunc := prefix + "_path_is"
+	dynamicFunc := prefix + "_dynamic"
+
+	io.WriteString(w, "# fish completion for "+program+"\n\n")
+
+	// dynamic
+	if c.hasDynamicCompletion() {
+		io.WriteString(w, "function "+dynamicFunc+"\n")
+		io.WriteString(w, "\tset -l tokens (commandline -opc)\n")
+		io.WriteString(w, "\tset -l current (commandline -ct)\n")
+		io.WriteString(w, "\t$tokens[1] "+CompleteCommandName+" $tokens[2..-1] \"$current\" 2>/dev/null\n")
+		io.WriteString(w, "end\n\n")
+	}
+
+	// value flags
+	io.WriteString(w, "function "+valueFlagsFunc+"\n")
+	io.WriteString(w, "\tswitch $argv[1]\n")
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		_, valueFlags := cmd.effectiveOptions().completionFlags()
+		if len(valueFlags) == 0 {
+			return
+		}
+		quotedFlags := make([]string, len(valueFlags))
+		for i, flag := range valueFlags {
+			quotedFlags[i] = fishQuote(flag)
+		}
+		io.WriteString(w, "\t\tcase "+fishQuote(strings.Join(cmdPaths, " "))+"\n")
+		io.WriteString(w, "\t\t\tprintf '%s\\n' "+strings.Join(quotedFlags, " ")+"\n")
+	})
+	io.WriteString(w, "\tend\n")
+	io.WriteString(w, "end\n\n")
+
+	// command path
+	io.WriteString(w, "function "+pathFunc+"\n")
+	io.WriteString(w, "\tset -l tokens (commandline -opc)\n")
+	io.WriteString(w, "\tset -l path "+fishQuote(program)+"\n")
+	io.WriteString(w, "\tset -l skip 0\n")
+	io.WriteString(w, "\tfor token in $tokens[2..-1]\n")
+	io.WriteString(w, "\t\tif test $skip -eq 1\n")
+	io.WriteString(w, "\t\t\tset skip 0\n")
+	io.WriteString(w, "\t\t\tcontinue\n")
+	io.WriteString(w, "\t\tend\n")
+	io.WriteString(w, "\t\tif contains -- $token ("+valueFlagsFunc+" $path)\n")
+	io.WriteString(w, "\t\t\tset skip 1\n")
+	io.WriteString(w, "\t\t\tcontinue\n")
+	io.WriteString(w, "\t\tend\n")
+	io.WriteString(w, "\t\tswitch \"$path/$token\"\n")
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		parentPath := strings.Join(cmdPaths, " ")
+		for _, subCmd := range cmd.subCommands {
+			patterns := make([]string, len(subCmd.names))
+			for i, name := range subCmd.names {
+				patterns[i] = fishQuote(parentPath + "/" + name)
+			}
+			io.WriteString(w, "\t\t\tcase "+strings.Join(patterns, " ")+"\n")
+			io.WriteString(w, "\t\t\t\tset path "+fishQuote(parentPath+" "+subCmd.Name())+"\n")
+		}
+	})
+	io.WriteString(w, "\t\tend\n")
+	io.WriteString(w, "\tend\n")
+	io.WriteString(w, "\techo $path\n")
+	io.WriteString(w, "end\n\n")
+
+	io.WriteString(w, "function "+pathIsFunc+"\n")
+	io.WriteString(w, "\tset -l path ("+pathFunc+")\n")
+	io.WriteString(w, "\ttest \"$path\" = \"$argv[1]\"\n")
+	io.WriteString(w, "end\n\n")
+
+	// completions
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		condition := pathIsFunc + " " + fishQuote(strings.Join(cmdPaths, " "))
+		completePrefix := "complete -c " + fishQuote(program)
+
+		for _, subCmd := range cmd.subCommands {
+			for _, name := range subCmd.names {
+				line := completePrefix + " -f -n " + fishQuote(condition) + " -a " + fishQuote(name)
+				if len(subCmd.summary) > 0 {
+					line += " -d " + fishQuote(subCmd.summary)
+				}
+				io.WriteString(w, line+"\n")
+			}
+		}
+
+		s := cmd.effectiveOptions()
+		for _, p := range s.positionals {
+			if p.Complete != nil {
+				io.WriteString(w, completePrefix+" -n "+fishQuote(condition)+" -a "+fishQuote("("+dynamicFunc+")")+"\n")
+				break
+			}
+		}
+
+		for _, opt := range s.options {
+			if opt.Hidden {
+				continue
+			}
+
+			optCondition := condition
+			exclusiveFlags := []string{}
+			for _, key := range s.exclusiveKeys(opt.Key) {
+				if exclusiveOpt := s.keyOptionMap[key]; exclusiveOpt != nil {
+					for _, flag := range exclusiveOpt.Flags {
+						exclusiveFlags = append(exclusiveFlags, fishFlagArgs(flag.Name))
+					}
+				}
+			}
+			if len(exclusiveFlags) > 0 {
+				optCondition += "; and not __fish_seen_argument " + strings.Join(exclusiveFlags, " ")
+			}
+
+			for _, flag := range opt.Flags {
+				line := completePrefix + " -n " + fishQuote(optCondition) + " " + fishFlagArgs(flag.Name)
+				if opt.AcceptValue {
+					line += " -r"
+				}
+				if opt.AcceptValue && opt.Complete != nil {
+					line += " -f -a " + fishQuote("("+dynamicFunc+")")
+				}
+				if len(opt.Summary) > 0 {
+					line += " -d " + fishQuote(opt.Summary)
+				}
+				io.WriteString(w, line+"\n")
+			}
+		}
+	})
+}
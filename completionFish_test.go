@@ -0,0 +1,49 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputFishCompletion(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.AddConflicts("hard", "soft")
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputFishCompletion(buffer)
+	script := buffer.String()
+
+	expects := []string{
+		"\t\tcase 'git remote set-url'\n\t\t\tprintf '%s\\n' '--dummy' '--dummy-x'\n",
+		"\t\t\tcase 'git/remote' 'git/rmt' 'git/rt'\n\t\t\t\tset path 'git remote'\n",
+		"complete -c 'git' -f -n '__git_path_is \\'git\\'' -a 'rmt' -d 'manage remotes'\n",
+		"complete -c 'git' -n '__git_path_is \\'git remote set-url\\'' -l 'dummy' -r -d 'dummy option'\n",
+		"complete -c 'git' -n '__git_path_is \\'git reset\\'; and not __fish_seen_argument -l \\'soft\\'' -l 'hard' -d 'hard reset'\n",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(script, expect) {
+			t.Error(expect)
+		}
+	}
+}
+
+func TestOutputFishCompletionConstraints(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.AddExclusive("hard", "mixed")
+	cmd.GetSubCommand("reset").options.AddConflicts("soft", "hard")
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputFishCompletion(buffer)
+	script := buffer.String()
+
+	expect := "complete -c 'git' -n '__git_path_is \\'git reset\\'; and not __fish_seen_argument -l \\'mixed\\' -l \\'soft\\'' -l 'hard' -d 'hard reset'\n" +
+		"complete -c 'git' -n '__git_path_is \\'git reset\\'; and not __fish_seen_argument -l \\'hard\\'' -l 'mixed' -d 'mixed reset'\n" +
+		"complete -c 'git' -n '__git_path_is \\'git reset\\'; and not __fish_seen_argument -l \\'hard\\'' -l 'soft' -d 'soft reset'\n"
+	if !strings.Contains(script, expect) {
+		t.Error(script)
+	}
+	if !strings.Contains(script, "-n '__git_path_is \\'git remote set-url\\'' -l 'push'") {
+		t.Error("option without constraint should have no exclusion condition")
+	}
+}
@@ -0,0 +1,157 @@
+package goNixArgParser
+
+import (
+	"io"
+	"strings"
+)
+
+var zshDescReplacer = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`, `:`, `\:`)
+
+func zshFuncName(cmdPaths []string) string {
+	return "_" + toIdentifier(strings.Join(cmdPaths, "_"))
+}
+
+func zshDynamicFuncName(program string) string {
+	return "_" + toIdentifier(program) + "_dynamic"
+}
+
+func (s *OptionSet) zshOptionSpecs(dynamicFunc string) []string {
+	specs := []string{}
+
+	for _, opt := range s.options {
+		if opt.Hidden {
+			continue
+		}
+
+		excludes := []string{}
+		if !opt.MultiValues {
+			for _, flag := range opt.Flags {
+				excludes = append(excludes, flag.Name)
+			}
+		}
+		for _, key := range s.exclusiveKeys(opt.Key) {
+			if exclusiveOpt := s.keyOptionMap[key]; exclusiveOpt != nil {
+				for _, flag := range exclusiveOpt.Flags {
+					excludes = append(excludes, flag.Name)
+				}
+			}
+		}
+
+		for _, flag := range opt.Flags {
+			spec := ""
+			if len(excludes) > 0 {
+				spec += "(" + strings.Join(excludes, " ") + ")"
+			}
+			if opt.MultiValues {
+				spec += "*"
+			}
+			spec += flag.Name
+			if len(opt.Summary) > 0 {
+				spec += "[" + zshDescReplacer.Replace(opt.Summary) + "]"
+			}
+			if opt.AcceptValue && opt.Complete != nil {
+				spec += ":value:" + dynamicFunc
+			} else if opt.AcceptValue {
+				spec += ":value:_files"
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
+
+func (c *Command) outputZshFunc(w io.Writer, cmdPaths []string) {
+	s := c.effectiveOptions()
+	dynamicFunc := zshDynamicFuncName(cmdPaths[0])
+	specs := s.zshOptionSpecs(dynamicFunc)
+	hasSubCommands := len(c.subCommands) > 0
+
+	io.WriteString(w, zshFuncName(cmdPaths)+"() {\n")
+	if hasSubCommands {
+		io.WriteString(w, "\tlocal context state state_descr line\n")
+		io.WriteString(w, "\ttypeset -A opt_args\n")
+		io.WriteString(w, "\t_arguments -C")
+		specs = append(specs, "1:command:->command", "*::arg:->args")
+	} else {
+		io.WriteString(w, "\t_arguments")
+		restsAction := "_files"
+		for _, p := range s.positionals {
+			if p.Complete != nil {
+				restsAction = dynamicFunc
+				break
+			}
+		}
+		specs = append(specs, "*:arg:"+restsAction)
+	}
+	for _, spec := range specs {
+		io.WriteString(w, " \\\n\t\t"+shellQuote(spec))
+	}
+	io.WriteString(w, "\n")
+
+	if hasSubCommands {
+		io.WriteString(w, "\n\tcase $state in\n")
+		io.WriteString(w, "\tcommand)\n")
+		io.WriteString(w, "\t\tlocal -a commands\n")
+		io.WriteString(w, "\t\tcommands=(\n")
+		for _, subCmd := range c.subCommands {
+			for _, name := range subCmd.names {
+				io.WriteString(w, "\t\t\t"+shellQuote(strings.ReplaceAll(name, ":", `\:`)+":"+subCmd.summary)+"\n")
+			}
+		}
+		io.WriteString(w, "\t\t)\n")
+		io.WriteString(w, "\t\t_describe -t commands command commands\n")
+		io.WriteString(w, "\t\t;;\n")
+		io.WriteString(w, "\targs)\n")
+		io.WriteString(w, "\t\tcase $line[1] in\n")
+		for _, subCmd := range c.subCommands {
+			names := make([]string, len(subCmd.names))
+			for i, name := range subCmd.names {
+				names[i] = shellQuote(name)
+			}
+			subPaths := append(cmdPaths[:len(cmdPaths):len(cmdPaths)], subCmd.Name())
+			io.WriteString(w, "\t\t"+strings.Join(names, "|")+")\n")
+			io.WriteString(w, "\t\t\t"+zshFuncName(subPaths)+"\n")
+			io.WriteString(w, "\t\t\t;;\n")
+		}
+		io.WriteString(w, "\t\tesac\n")
+		io.WriteString(w, "\t\t;;\n")
+		io.WriteString(w, "\tesac\n")
+	}
+
+	io.WriteString(w, "}\n\n")
+}
+
+func (c *Command) OutputZshCompletion(w io.Writer) {
+	program := c.programName()
+	rootFuncName := zshFuncName([]string{program})
+
+	io.WriteString(w, "#compdef "+program+"\n\n")
+
+	if c.hasDynamicCompletion() {
+		io.WriteString(w, zshDynamicFuncName(program)+"() {\n")
+		io.WriteString(w, "\tlocal -a args candidates\n")
+		io.WriteString(w, "\targs=(${(z)LBUFFER})\n")
+		io.WriteString(w, "\tif [[ \"$LBUFFER\" == *[[:space:]] ]]; then\n")
+		io.WriteString(w, "\t\targs+=('')\n")
+		io.WriteString(w, "\tfi\n")
+		io.WriteString(w, "\tcandidates=(${(f)\"$(${args[1]} "+CompleteCommandName+" \"${(@)args[2,-1]}\" 2>/dev/null)\"})\n")
+		io.WriteString(w, "\tif (( ${#candidates} )); then\n")
+		io.WriteString(w, "\t\tcompadd -a candidates\n")
+		io.WriteString(w, "\telse\n")
+		io.WriteString(w, "\t\t_files\n")
+		io.WriteString(w, "\tfi\n")
+		io.WriteString(w, "}\n\n")
+	}
+
+	c.walk(nil, func(cmd *Command, cmdPaths []string) {
+		cmdPaths[0] = program
+		cmd.outputZshFunc(w, cmdPaths)
+	})
+
+	io.WriteString(w, "if [[ \"$funcstack[1]\" == "+shellQuote(rootFuncName)+" ]]; then\n")
+	io.WriteString(w, "\t"+rootFuncName+" \"$@\"\n")
+	io.WriteString(w, "else\n")
+	io.WriteString(w, "\tcompdef "+rootFuncName+" "+program+"\n")
+	io.WriteString(w, "fi\n")
+}
@@ -0,0 +1,51 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputZshCompletion(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.AddExclusive("hard", "mixed", "soft")
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputZshCompletion(buffer)
+	script := buffer.String()
+
+	expects := []string{
+		"#compdef git\n",
+		"_git_remote_set_url() {\n\t_arguments \\\n\t\t'(--push)--push' \\\n\t\t'(--dummy)--dummy[dummy option]:value:_files' \\\n",
+		"\t\t\t'rmt:manage remotes'\n",
+		"\t\t'remote'|'rmt'|'rt')\n\t\t\t_git_remote\n",
+		"'(--hard --mixed --soft)--hard[hard reset]'",
+		"\tcompdef _git git\n",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(script, expect) {
+			t.Error(expect)
+		}
+	}
+}
+
+func TestOutputZshCompletionConstraints(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.AddExclusive("hard", "mixed")
+	cmd.GetSubCommand("reset").options.AddConflicts("soft", "hard")
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputZshCompletion(buffer)
+	script := buffer.String()
+
+	expect := "_git_reset() {\n\t_arguments \\\n" +
+		"\t\t'(--hard --mixed --soft)--hard[hard reset]' \\\n" +
+		"\t\t'(--mixed --hard)--mixed[mixed reset]' \\\n" +
+		"\t\t'(--soft --hard)--soft[soft reset]' \\\n"
+	if !strings.Contains(script, expect) {
+		t.Error(script)
+	}
+	if !strings.Contains(script, "\t\t'(--push)--push' \\\n") {
+		t.Error("option without constraint should only exclude itself")
+	}
+}
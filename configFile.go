@@ -0,0 +1,149 @@
+package goNixArgParser
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrUnknownConfigKey     = errors.New("unknown config key")
+	ErrInvalidConfigValue   = errors.New("invalid config value")
+	ErrConfigMultipleValues = errors.New("option does not accept multiple values")
+)
+
+type ConfigError struct {
+	File string
+	Line int
+	Key  string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	msg := ""
+	if len(e.File) > 0 {
+		msg += e.File
+		if e.Line > 0 {
+			msg += ":" + strconv.Itoa(e.Line)
+		}
+		msg += ": "
+	} else if e.Line > 0 {
+		msg += "line " + strconv.Itoa(e.Line) + ": "
+	}
+	if len(e.Key) > 0 {
+		msg += "key '" + e.Key + "': "
+	}
+	return msg + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func configScalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func configValues(opt *Option, value interface{}) (values []string, set bool, err error) {
+	if value == nil {
+		return nil, false, nil
+	}
+
+	if !opt.AcceptValue {
+		str, ok := configScalarString(value)
+		if !ok {
+			return nil, false, ErrInvalidConfigValue
+		}
+		set, err = toBool(str)
+		if err != nil {
+			return nil, false, ErrInvalidConfigValue
+		}
+		return []string{}, set, nil
+	}
+
+	if items, isArray := value.([]interface{}); isArray {
+		if !opt.MultiValues {
+			return nil, false, ErrConfigMultipleValues
+		}
+		values = make([]string, len(items))
+		for i, item := range items {
+			str, ok := configScalarString(item)
+			if !ok {
+				return nil, false, ErrInvalidConfigValue
+			}
+			values[i] = str
+		}
+		return values, true, nil
+	}
+
+	str, ok := configScalarString(value)
+	if !ok {
+		return nil, false, ErrInvalidConfigValue
+	}
+	if opt.MultiValues {
+		return opt.splitValues(str), true, nil
+	}
+	return []string{str}, true, nil
+}
+
+func (c *Command) root() *Command {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+func (c *Command) isSelfOrAncestorOf(cmd *Command) bool {
+	for ; cmd != nil; cmd = cmd.parent {
+		if cmd == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ParseResult) getShadowedConfigs(key string) []ValueOrigin {
+	prevValues, found := r.configOptions[key]
+	if !found {
+		return r.configShadowed[key]
+	}
+	prev := ValueOrigin{Source: ConfigSource, Values: copys(prevValues), Flag: r.configFlags[key], File: r.configFiles[key]}
+	return append([]ValueOrigin{prev}, r.configShadowed[key]...)
+}
+
+func (r *ParseResult) unsetConfigFileOption(key string) {
+	shadowed := r.getShadowedConfigs(key)
+
+	delete(r.configOptions, key)
+	delete(r.configFlags, key)
+	delete(r.configFiles, key)
+	if len(shadowed) > 0 {
+		r.configShadowed[key] = shadowed
+	}
+
+	if opt := r.keyOptionMap[key]; opt != nil {
+		r.parseValue(opt)
+	}
+}
+
+func (r *ParseResult) setConfigFileOptions(file, key string, values []string) {
+	shadowed := r.getShadowedConfigs(key)
+
+	r.SetConfigOptions(key, values)
+	if len(file) > 0 {
+		r.configFiles[key] = file
+	}
+	if len(shadowed) > 0 {
+		r.configShadowed[key] = shadowed
+	}
+}
@@ -0,0 +1,311 @@
+package goNixArgParser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var errInvalidINILine = errors.New("invalid config line, expect 'key = value' or '[section]'")
+
+type iniSection struct {
+	group  int
+	cmd    *Command
+	depth  int
+	prefix string
+}
+
+type iniEntry struct {
+	section *iniSection
+	key     string
+	values  []string
+	set     bool
+}
+
+type iniConfig struct {
+	file         string
+	rootCmd      *Command
+	keyOptionMap map[string]*Option
+	entries      []*iniEntry
+	errs         ErrorList
+}
+
+func (c *iniConfig) addError(line int, key string, err error) {
+	c.errs = append(c.errs, &ConfigError{File: c.file, Line: line, Key: key, Err: err})
+}
+
+// =============================
+// values
+// =============================
+
+func parseINIQuoted(input string) (value, rest string, err error) {
+	quote := input[0]
+	end := 1
+	for ; end < len(input); end++ {
+		if input[end] == '\\' && quote == '"' {
+			end++
+		} else if input[end] == quote {
+			break
+		}
+	}
+	if end >= len(input) {
+		return "", "", ErrInvalidConfigValue
+	}
+
+	if quote == '"' {
+		value, err = strconv.Unquote(input[:end+1])
+		if err != nil {
+			return "", "", ErrInvalidConfigValue
+		}
+	} else {
+		value = input[1:end]
+	}
+	return value, strings.TrimSpace(input[end+1:]), nil
+}
+
+func parseINIValue(input string) (interface{}, error) {
+	if len(input) == 0 {
+		return "", nil
+	}
+
+	if input[0] == '"' || input[0] == '\'' {
+		value, rest, err := parseINIQuoted(input)
+		if err != nil || len(rest) > 0 {
+			return nil, ErrInvalidConfigValue
+		}
+		return value, nil
+	}
+
+	if input[0] != '[' {
+		return input, nil
+	}
+	if input[len(input)-1] != ']' {
+		return nil, ErrInvalidConfigValue
+	}
+
+	items := []interface{}{}
+	rest := strings.TrimSpace(input[1 : len(input)-1])
+	for len(rest) > 0 {
+		var item string
+		if rest[0] == '"' || rest[0] == '\'' {
+			var err error
+			item, rest, err = parseINIQuoted(rest)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			item, rest = strings.TrimSpace(rest[:end]), rest[end:]
+		}
+		items = append(items, item)
+
+		if len(rest) > 0 {
+			if rest[0] != ',' {
+				return nil, ErrInvalidConfigValue
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+	}
+	return items, nil
+}
+
+// strip unquoted inline comment leading by whitespace and '#' or ';'
+func trimINIComment(input string) string {
+	var quote byte
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+		if quote != 0 {
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		// quotes inside bare text like it's are literal
+		if (ch == '"' || ch == '\'') && (i == 0 || strings.IndexByte(" \t[,", input[i-1]) >= 0) {
+			quote = ch
+		} else if (ch == '#' || ch == ';') && (i == 0 || input[i-1] == ' ' || input[i-1] == '\t') {
+			return input[:i]
+		}
+	}
+	return input
+}
+
+// =============================
+// lines
+// =============================
+
+func (c *iniConfig) parseSection(line int, name string) *iniSection {
+	section := &iniSection{cmd: c.rootCmd, prefix: name + "."}
+
+	names := strings.Split(name, ".")
+	if group, err := strconv.Atoi(names[0]); err == nil && group >= 0 {
+		section.group = group
+		names = names[1:]
+	}
+
+	for _, cmdName := range names {
+		var subCmd *Command
+		if section.cmd != nil {
+			subCmd = section.cmd.GetSubCommand(strings.TrimSpace(cmdName))
+		}
+		if subCmd == nil {
+			c.addError(line, name, ErrUnknownConfigKey)
+			return nil
+		}
+		section.cmd = subCmd
+		section.depth++
+	}
+
+	return section
+}
+
+func (c *iniConfig) getEntry(section *iniSection, key string) *iniEntry {
+	for _, entry := range c.entries {
+		if entry.section == section && entry.key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (c *iniConfig) parseKeyValue(line int, section *iniSection, key, rawValue string) {
+	keyOptionMap := c.keyOptionMap
+	if section.cmd != nil {
+		keyOptionMap = section.cmd.effectiveOptions().keyOptionMap
+	}
+	opt := keyOptionMap[key]
+	if opt == nil {
+		c.addError(line, section.prefix+key, ErrUnknownConfigKey)
+		return
+	}
+
+	value, err := parseINIValue(rawValue)
+	if err == nil {
+		var values []string
+		var set bool
+		values, set, err = configValues(opt, value)
+		if err == nil {
+			entry := c.getEntry(section, key)
+			if entry == nil {
+				c.entries = append(c.entries, &iniEntry{section: section, key: key, values: values, set: set})
+			} else if opt.MultiValues {
+				entry.values = append(entry.values, values...)
+				entry.set = entry.set || set
+			} else {
+				err = ErrConfigMultipleValues
+			}
+		}
+	}
+	if err != nil {
+		c.addError(line, section.prefix+key, err)
+	}
+}
+
+func (c *iniConfig) parse(data []byte) {
+	section := &iniSection{cmd: c.rootCmd}
+	unknownPrefix := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+
+		if text[0] == '[' {
+			if text[len(text)-1] != ']' {
+				c.addError(line, "", errInvalidINILine)
+				section = nil
+				unknownPrefix = ""
+				continue
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			section = c.parseSection(line, name)
+			unknownPrefix = name + "."
+			continue
+		}
+
+		equalIndex := strings.IndexByte(text, '=')
+		if equalIndex <= 0 {
+			c.addError(line, "", errInvalidINILine)
+			continue
+		}
+		key := strings.TrimSpace(text[:equalIndex])
+		if section == nil {
+			c.addError(line, unknownPrefix+key, ErrUnknownConfigKey)
+			continue
+		}
+		value := strings.TrimSpace(trimINIComment(text[equalIndex+1:]))
+		c.parseKeyValue(line, section, key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		c.errs = append(c.errs, &ConfigError{File: c.file, Err: err})
+	}
+}
+
+// =============================
+// apply
+// =============================
+
+func (c *iniConfig) apply(results []*ParseResult) {
+	entries := make([]*iniEntry, len(c.entries))
+	copy(entries, c.entries)
+	// options of deeper sub commands take precedence
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].section.depth < entries[j].section.depth
+	})
+
+	for _, entry := range entries {
+		if entry.section.group >= len(results) {
+			continue
+		}
+		result := results[entry.section.group]
+		if entry.section.cmd != nil && !entry.section.cmd.isSelfOrAncestorOf(result.command) {
+			continue
+		}
+		if result.keyOptionMap[entry.key] == nil {
+			continue
+		}
+		if entry.set {
+			result.setConfigFileOptions(c.file, entry.key, entry.values)
+		} else {
+			result.unsetConfigFileOption(entry.key)
+		}
+	}
+}
+
+func applyINIConfig(file string, data []byte, results []*ParseResult) error {
+	c := &iniConfig{file: file, keyOptionMap: map[string]*Option{}}
+	if len(results) > 0 {
+		if results[0].command != nil {
+			c.rootCmd = results[0].command.root()
+		}
+		c.keyOptionMap = results[0].keyOptionMap
+	}
+
+	c.parse(data)
+	c.apply(results)
+	return c.errs.orNil()
+}
+
+func ApplyINIConfig(data []byte, results ...*ParseResult) error {
+	return applyINIConfig("", data, results)
+}
+
+func LoadINIConfig(filename string, results ...*ParseResult) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return applyINIConfig(filename, data, results)
+}
@@ -0,0 +1,203 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseINIValue(t *testing.T) {
+	value, err := parseINIValue(`"a \"quoted\" value"`)
+	if err != nil || value != `a "quoted" value` {
+		t.Error(value, err)
+	}
+
+	value, err = parseINIValue(`'C:\path'`)
+	if err != nil || value != `C:\path` {
+		t.Error(value, err)
+	}
+
+	value, err = parseINIValue(`[a, "b, c", 'd' ,]`)
+	items, _ := value.([]interface{})
+	if err != nil || len(items) != 3 || items[0] != "a" || items[1] != "b, c" || items[2] != "d" {
+		t.Error(value, err)
+	}
+
+	if _, err = parseINIValue(`"unclosed`); err == nil {
+		t.Error("should be error")
+	}
+	if _, err = parseINIValue(`[a b" c]`); err != nil {
+		t.Error(err)
+	}
+	if _, err = parseINIValue(`["a" b]`); err == nil {
+		t.Error("should be error")
+	}
+}
+
+func TestTrimINIComment(t *testing.T) {
+	if value := trimINIComment(` a b # comment`); value != ` a b ` {
+		t.Error(value)
+	}
+	if value := trimINIComment(` a#b;c`); value != ` a#b;c` {
+		t.Error(value)
+	}
+	if value := trimINIComment(` "a # \" ; b" ; comment`); value != ` "a # \" ; b" ` {
+		t.Error(value)
+	}
+	if value := trimINIComment(` it's # comment`); value != ` it's ` {
+		t.Error(value)
+	}
+	if value := trimINIComment(` [a, '#b', c] # comment`); value != ` [a, '#b', c] ` {
+		t.Error(value)
+	}
+}
+
+func TestApplyINIConfig(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"-v"}), Persistent: true})
+	cmd.GetSubCommand("remote").GetSubCommand("set-url").options.AddFlagValues("tags", "--tags", "", nil, "")
+
+	result := cmd.Parse([]string{"git", "remote", "set-url", "--dummy", "dummy0"}, nil)
+	err := ApplyINIConfig([]byte(`
+# comment
+verbose = true
+
+[remote.set-url]
+; comment
+push = false
+dummy = dummyConfig
+dummyX = "dummy x" # comment
+tags = [a, b]
+tags = c ; comment
+
+[reset]
+hard = true
+`), result)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !result.HasKey("verbose") {
+		t.Error("verbose")
+	}
+	if result.HasKey("push") {
+		t.Error("push")
+	}
+	if dummy, _ := result.GetString("dummy"); dummy != "dummy0" {
+		t.Error(dummy)
+	}
+	if dummyX, _ := result.GetString("dummyX"); dummyX != "dummy x" {
+		t.Error(dummyX)
+	}
+	if tags, _ := result.GetStrings("tags"); len(tags) != 3 || tags[2] != "c" {
+		t.Error(tags)
+	}
+}
+
+func TestApplyINIConfigErrors(t *testing.T) {
+	cmd := getGitCommand()
+	result := cmd.Parse([]string{"git", "remote", "set-url"}, nil)
+
+	err := ApplyINIConfig([]byte(`no-such-key = 1
+[remote.set-url]
+dummy = a
+dummy = b
+push = yes
+dummyX = x
+[no-such-cmd]
+key = value
+invalid line
+[reset]
+harder = true
+`), result)
+
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 7 {
+		t.Fatal(err)
+	}
+	if errs[0].Error() != "line 1: key 'no-such-key': unknown config key" {
+		t.Error(errs[0])
+	}
+	if configErr := errs[1].(*ConfigError); configErr.Line != 4 || !errors.Is(configErr, ErrConfigMultipleValues) {
+		t.Error(errs[1])
+	}
+	if configErr := errs[2].(*ConfigError); configErr.Line != 5 || !errors.Is(configErr, ErrInvalidConfigValue) {
+		t.Error(errs[2])
+	}
+	if configErr := errs[3].(*ConfigError); configErr.Line != 7 || configErr.Key != "no-such-cmd" {
+		t.Error(errs[3])
+	}
+	if errs[4].Error() != "line 8: key 'no-such-cmd.key': unknown config key" {
+		t.Error(errs[4])
+	}
+	if configErr := errs[5].(*ConfigError); configErr.Line != 9 {
+		t.Error(errs[5])
+	}
+	if errs[6].Error() != "line 11: key 'reset.harder': unknown config key" {
+		t.Error(errs[6])
+	}
+
+	if dummy, _ := result.GetString("dummy"); dummy != "a" {
+		t.Error(dummy)
+	}
+	if dummyX, _ := result.GetString("dummyX"); dummyX != "x" {
+		t.Error(dummyX)
+	}
+}
+
+func TestLoadINIConfigGroups(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.ini")
+	os.WriteFile(filename, []byte("[remote.set-url]\ndummy = a\n[1.remote.set-url]\ndummy = b\n[1.no-such-cmd]\n"), 0644)
+
+	cmd := getGitCommand()
+	results := cmd.ParseGroups([]string{"git", "remote", "set-url", "github", ",,", "bitbucket"}, nil)
+	err := LoadINIConfig(filename, results...)
+	if err == nil || err.Error() != filename+":5: key '1.no-such-cmd': unknown config key" {
+		t.Error(err)
+	}
+
+	if dummy, _ := results[0].GetString("dummy"); dummy != "a" {
+		t.Error(dummy)
+	}
+	if dummy, _ := results[1].GetString("dummy"); dummy != "b" {
+		t.Error(dummy)
+	}
+
+	p := results[1].GetProvenance("dummy")
+	if p.Source != ConfigSource || p.File != filename {
+		t.Error(p)
+	}
+}
+
+func TestLoadINIConfigFalseFlag(t *testing.T) {
+	dir := t.TempDir()
+	systemFile := filepath.Join(dir, "system.ini")
+	userFile := filepath.Join(dir, "user.ini")
+	os.WriteFile(systemFile, []byte("verbose = true\nquiet = true\n"), 0644)
+	os.WriteFile(userFile, []byte("verbose = false\n"), 0644)
+
+	s := NewSimpleOptionSet()
+	s.AddFlag("verbose", "-v", "", "")
+	s.AddFlag("quiet", "-q", "", "")
+
+	result := s.Parse(nil, []string{"-v"})
+	if err := LoadINIConfig(systemFile, result); err != nil {
+		t.Error(err)
+	}
+	if err := LoadINIConfig(userFile, result); err != nil {
+		t.Error(err)
+	}
+
+	if result.HasKey("verbose") {
+		t.Error("verbose")
+	}
+	if !result.HasKey("quiet") {
+		t.Error("quiet")
+	}
+
+	p := result.GetProvenance("verbose")
+	if len(p.Shadowed) != 2 || p.Shadowed[0].File != systemFile || p.Shadowed[1].Flag != "-v" {
+		t.Error(p)
+	}
+}
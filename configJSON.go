@@ -0,0 +1,137 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+)
+
+type jsonConfig struct {
+	file         string
+	result       *ParseResult // nil if no result to apply to, only validate
+	keyOptionMap map[string]*Option
+	errs         ErrorList
+}
+
+func (c *jsonConfig) addError(key string, err error) {
+	c.errs = append(c.errs, &ConfigError{File: c.file, Key: key, Err: err})
+}
+
+func (c *jsonConfig) applyObject(obj map[string]interface{}, keyPrefix string, cmd *Command, onPath bool) {
+	keyOptionMap := c.keyOptionMap
+	if cmd != nil {
+		keyOptionMap = cmd.effectiveOptions().keyOptionMap
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	subObjectKeys := []string{}
+	for _, key := range keys {
+		value := obj[key]
+		if _, isObject := value.(map[string]interface{}); isObject {
+			subObjectKeys = append(subObjectKeys, key)
+			continue
+		}
+
+		opt := keyOptionMap[key]
+		if opt == nil {
+			c.addError(keyPrefix+key, ErrUnknownConfigKey)
+			continue
+		}
+
+		values, set, err := configValues(opt, value)
+		if err != nil {
+			c.addError(keyPrefix+key, err)
+			continue
+		}
+		if value == nil || !onPath || c.result == nil || c.result.keyOptionMap[key] == nil {
+			continue
+		}
+		if set {
+			c.result.setConfigFileOptions(c.file, key, values)
+		} else {
+			c.result.unsetConfigFileOption(key)
+		}
+	}
+
+	// options of deeper sub commands take precedence
+	for _, key := range subObjectKeys {
+		var subCmd *Command
+		if cmd != nil {
+			subCmd = cmd.GetSubCommand(key)
+		}
+		if subCmd == nil {
+			c.addError(keyPrefix+key, ErrUnknownConfigKey)
+			continue
+		}
+
+		subOnPath := onPath && c.result != nil && subCmd.isSelfOrAncestorOf(c.result.command)
+		c.applyObject(obj[key].(map[string]interface{}), keyPrefix+key+".", subCmd, subOnPath)
+	}
+}
+
+func applyJSONConfig(file string, data []byte, results []*ParseResult) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return &ConfigError{File: file, Err: err}
+	}
+
+	var groups []interface{}
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		groups = []interface{}{v}
+	case []interface{}:
+		groups = v
+	default:
+		return &ConfigError{File: file, Err: errors.New("config must be an object or an array of objects")}
+	}
+
+	var rootCmd *Command
+	keyOptionMap := map[string]*Option{}
+	if len(results) > 0 {
+		if results[0].command != nil {
+			rootCmd = results[0].command.root()
+		}
+		keyOptionMap = results[0].keyOptionMap
+	}
+
+	errs := ErrorList{}
+	for i, group := range groups {
+		c := &jsonConfig{file: file, keyOptionMap: keyOptionMap}
+		if i < len(results) {
+			c.result = results[i]
+		}
+
+		obj, isObject := group.(map[string]interface{})
+		if !isObject {
+			c.addError("["+strconv.Itoa(i)+"]", ErrInvalidConfigValue)
+		} else {
+			c.applyObject(obj, "", rootCmd, true)
+		}
+		errs = append(errs, c.errs...)
+	}
+
+	return errs.orNil()
+}
+
+func ApplyJSONConfig(data []byte, results ...*ParseResult) error {
+	return applyJSONConfig("", data, results)
+}
+
+func LoadJSONConfig(filename string, results ...*ParseResult) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return applyJSONConfig(filename, data, results)
+}
@@ -0,0 +1,146 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyJSONConfig(t *testing.T) {
+	cmd := getGitCommand()
+	cmd.options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"-v"}), Persistent: true})
+	cmd.GetSubCommand("remote").GetSubCommand("set-url").options.AddFlagValues("tags", "--tags", "", nil, "")
+
+	result := cmd.Parse([]string{"git", "remote", "set-url", "--dummy", "dummy0"}, nil)
+	err := ApplyJSONConfig([]byte(`{
+		"verbose": true,
+		"remote": {
+			"set-url": {
+				"push": false,
+				"dummy": "dummyConfig",
+				"dummyX": 123,
+				"tags": ["a", "b", "a"]
+			}
+		},
+		"reset": {"hard": true}
+	}`), result)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !result.HasKey("verbose") {
+		t.Error("verbose")
+	}
+	if result.HasKey("push") {
+		t.Error("push")
+	}
+	if dummy, _ := result.GetString("dummy"); dummy != "dummy0" {
+		t.Error(dummy)
+	}
+	if dummyX, _ := result.GetString("dummyX"); dummyX != "123" {
+		t.Error(dummyX)
+	}
+	if tags, _ := result.GetStrings("tags"); len(tags) != 2 {
+		t.Error(tags)
+	}
+
+	p := result.GetProvenance("dummy")
+	if len(p.Shadowed) != 1 || p.Shadowed[0].Source != ConfigSource || p.Shadowed[0].Values[0] != "dummyConfig" {
+		t.Error(p)
+	}
+}
+
+func TestApplyJSONConfigErrors(t *testing.T) {
+	cmd := getGitCommand()
+	result := cmd.Parse([]string{"git", "remote", "set-url"}, nil)
+
+	err := ApplyJSONConfig([]byte(`{
+		"no-such-key": 1,
+		"remote": {"set-url": {"dummy": ["a", "b"], "push": "yes", "dummyX": "x"}},
+		"reset": {"harder": true}
+	}`), result)
+
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 4 {
+		t.Fatal(err)
+	}
+	if errs[0].Error() != "key 'no-such-key': unknown config key" {
+		t.Error(errs[0])
+	}
+	if !errors.Is(errs[1], ErrConfigMultipleValues) {
+		t.Error(errs[1])
+	}
+	if !errors.Is(errs[2], ErrInvalidConfigValue) {
+		t.Error(errs[2])
+	}
+	if errs[3].Error() != "key 'reset.harder': unknown config key" {
+		t.Error(errs[3])
+	}
+
+	if dummyX, _ := result.GetString("dummyX"); dummyX != "x" {
+		t.Error(dummyX)
+	}
+
+	if err = ApplyJSONConfig([]byte(`"text"`), result); err == nil {
+		t.Error("should be error")
+	}
+}
+
+func TestLoadJSONConfigGroups(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(filename, []byte(`[{"deft": "a"}, {"deft": "b"}, {"deft": "c"}]`), 0644)
+
+	s := NewSimpleOptionSet()
+	s.AddFlagValue("deft", "--deft", "", "", "")
+	s.AddFlag("flag", "-f", "", "")
+
+	results := s.ParseGroups([]string{"-f", ",,", "--deft", "x"}, nil)
+	if err := LoadJSONConfig(filename, results...); err != nil {
+		t.Error(err)
+	}
+
+	if deft, _ := results[0].GetString("deft"); deft != "a" {
+		t.Error(deft)
+	}
+	if deft, _ := results[1].GetString("deft"); deft != "x" {
+		t.Error(deft)
+	}
+
+	p := results[0].GetProvenance("deft")
+	if p.File != filename || p.String() != "deft: config in "+filename+` "a"` {
+		t.Error(p)
+	}
+}
+
+func TestLoadJSONConfigFalseFlag(t *testing.T) {
+	dir := t.TempDir()
+	systemFile := filepath.Join(dir, "system.json")
+	userFile := filepath.Join(dir, "user.json")
+	os.WriteFile(systemFile, []byte(`{"verbose": true, "quiet": true}`), 0644)
+	os.WriteFile(userFile, []byte(`{"verbose": false, "quiet": null}`), 0644)
+
+	s := NewSimpleOptionSet()
+	s.AddFlag("verbose", "-v", "", "")
+	s.AddFlag("quiet", "-q", "", "")
+
+	result := s.Parse(nil, nil)
+	if err := LoadJSONConfig(systemFile, result); err != nil {
+		t.Error(err)
+	}
+	if err := LoadJSONConfig(userFile, result); err != nil {
+		t.Error(err)
+	}
+
+	if result.HasKey("verbose") {
+		t.Error("verbose")
+	}
+	if !result.HasKey("quiet") {
+		t.Error("quiet")
+	}
+
+	p := result.GetProvenance("verbose")
+	if p.Source != NoneSource || p.String() != "verbose: not set; shadows config in "+systemFile {
+		t.Error(p)
+	}
+}
@@ -0,0 +1,35 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+func LoadConfigFile(filename string, results ...*ParseResult) error {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return LoadJSONConfig(filename, results...)
+	default:
+		return LoadINIConfig(filename, results...)
+	}
+}
+
+// LoadConfigFiles applies config files from the lowest precedence to the highest,
+// non-existent files are skipped.
+func LoadConfigFiles(filenames []string, results ...*ParseResult) error {
+	errs := ErrorList{}
+	for _, filename := range filenames {
+		err := LoadConfigFile(filename, results...)
+		if err == nil || errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if list, ok := err.(ErrorList); ok {
+			errs = append(errs, list...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	return errs.orNil()
+}
@@ -0,0 +1,62 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigFiles(t *testing.T) {
+	t.Setenv("TEST_CONFIG_LAYERS_USER", "envUser")
+
+	dir := t.TempDir()
+	systemFile := filepath.Join(dir, "system.ini")
+	userFile := filepath.Join(dir, "user.json")
+	projectFile := filepath.Join(dir, "project.conf")
+	os.WriteFile(systemFile, []byte("host = system.host\nport = 1\nuser = systemUser\nlevel = 1\n"), 0644)
+	os.WriteFile(userFile, []byte(`{"port": 2, "user": "userUser", "unknown": 0}`), 0644)
+	os.WriteFile(projectFile, []byte("port = 3\n"), 0644)
+
+	s := NewSimpleOptionSet()
+	s.AddFlagValue("host", "--host", "", "", "")
+	s.AddFlagValue("port", "--port", "", "", "")
+	s.AddFlagValue("user", "--user", "TEST_CONFIG_LAYERS_USER", "", "")
+	s.AddFlagValue("level", "--level", "", "", "")
+
+	result := s.Parse([]string{"--level", "9"}, []string{"--host", "args.host"})
+	err := LoadConfigFiles([]string{systemFile, filepath.Join(dir, "not-exists.ini"), userFile, projectFile}, result)
+
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(errs[0], ErrUnknownConfigKey) {
+		t.Error(err)
+	}
+
+	if host, _ := result.GetString("host"); host != "system.host" {
+		t.Error(host)
+	}
+	if user, _ := result.GetString("user"); user != "envUser" {
+		t.Error(user)
+	}
+	if level, _ := result.GetString("level"); level != "9" {
+		t.Error(level)
+	}
+
+	p := result.GetProvenance("port")
+	if p.Source != ConfigSource || p.File != projectFile || p.Values[0] != "3" {
+		t.Error(p)
+	}
+	if len(p.Shadowed) != 2 || p.Shadowed[0].File != userFile || p.Shadowed[1].File != systemFile {
+		t.Error(p.Shadowed)
+	}
+
+	p = result.GetProvenance("host")
+	if p.String() != `host: config in `+systemFile+` "system.host"; shadows config --host "args.host"` {
+		t.Error(p.String())
+	}
+
+	result.SetConfigOption("port", "4")
+	if p = result.GetProvenance("port"); len(p.Shadowed) != 0 {
+		t.Error(p)
+	}
+}
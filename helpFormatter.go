@@ -0,0 +1,243 @@
+package goNixArgParser
+
+import (
+	"io"
+	"strings"
+)
+
+type HelpFormatter interface {
+	OutputOptions(w io.Writer, title string, options []*Option)
+	OutputPositionals(w io.Writer, title string, positionals []*Positional)
+	OutputCommands(w io.Writer, title string, commands []*Command)
+}
+
+func outputHelpTitle(w io.Writer, title string) {
+	if len(title) > 0 {
+		io.WriteString(w, "\n"+title+":\n\n")
+	}
+}
+
+// =============================
+// default formatter
+// =============================
+
+type defaultHelpFormatter struct{}
+
+var DefaultHelpFormatter HelpFormatter = defaultHelpFormatter{}
+
+func (defaultHelpFormatter) OutputOptions(w io.Writer, title string, options []*Option) {
+	newline := []byte{'\n'}
+
+	outputHelpTitle(w, title)
+	for _, opt := range options {
+		opt.OutputHelp(w)
+		w.Write(newline)
+	}
+}
+
+func (defaultHelpFormatter) OutputPositionals(w io.Writer, title string, positionals []*Positional) {
+	newline := []byte{'\n'}
+
+	outputHelpTitle(w, title)
+	for _, p := range positionals {
+		p.OutputHelp(w)
+		w.Write(newline)
+	}
+}
+
+func (defaultHelpFormatter) OutputCommands(w io.Writer, title string, commands []*Command) {
+	newline := []byte{'\n'}
+
+	outputHelpTitle(w, title)
+	for _, cmd := range commands {
+		io.WriteString(w, cmd.Name())
+		w.Write(newline)
+		if len(cmd.summary) > 0 {
+			io.WriteString(w, cmd.summary)
+			w.Write(newline)
+		}
+		w.Write(newline)
+	}
+}
+
+// =============================
+// column formatter
+// =============================
+
+type ColumnHelpFormatter struct {
+	Width        int // wrap details to this width, 0 to disable wrapping
+	Indent       int // spaces before terms
+	Gap          int // minimal spaces between terms and details
+	MaxTermWidth int // details of longer terms start from next line
+}
+
+func NewColumnHelpFormatter(width int) *ColumnHelpFormatter {
+	return &ColumnHelpFormatter{
+		Width:        width,
+		Indent:       2,
+		Gap:          2,
+		MaxTermWidth: 30,
+	}
+}
+
+type helpEntry struct {
+	term    string
+	details []string
+}
+
+func wrapText(text string, width int) []string {
+	lines := []string{}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := words[0]
+		for _, word := range words[1:] {
+			if width > 0 && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = word
+			} else {
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func (f *ColumnHelpFormatter) outputEntries(w io.Writer, title string, entries []*helpEntry) {
+	outputHelpTitle(w, title)
+
+	termWidth := 0
+	for _, entry := range entries {
+		if len(entry.term) > termWidth && len(entry.term) <= f.MaxTermWidth {
+			termWidth = len(entry.term)
+		}
+	}
+
+	detailIndent := f.Indent + termWidth + f.Gap
+	detailWidth := 0
+	if f.Width > 0 {
+		detailWidth = f.Width - detailIndent
+		if detailWidth < 1 {
+			detailWidth = 1
+		}
+	}
+
+	indent := strings.Repeat(" ", f.Indent)
+	padding := strings.Repeat(" ", detailIndent)
+
+	for _, entry := range entries {
+		lines := []string{}
+		for _, detail := range entry.details {
+			lines = append(lines, wrapText(detail, detailWidth)...)
+		}
+
+		io.WriteString(w, indent+entry.term)
+		if len(lines) > 0 && len(entry.term) <= termWidth {
+			io.WriteString(w, strings.Repeat(" ", detailIndent-f.Indent-len(entry.term))+lines[0])
+			lines = lines[1:]
+		}
+		io.WriteString(w, "\n")
+
+		for _, line := range lines {
+			if len(line) == 0 {
+				io.WriteString(w, "\n")
+			} else {
+				io.WriteString(w, padding+line+"\n")
+			}
+		}
+	}
+}
+
+func (f *ColumnHelpFormatter) OutputOptions(w io.Writer, title string, options []*Option) {
+	entries := make([]*helpEntry, 0, len(options))
+	for _, opt := range options {
+		if opt.Hidden {
+			continue
+		}
+
+		term := strings.Join(opt.flagNames(), ", ")
+		if opt.AcceptValue {
+			term += " " + opt.valueUsage()
+		}
+		if opt.Required {
+			term += " (required)"
+		}
+
+		details := []string{}
+		if len(opt.Summary) > 0 {
+			details = append(details, opt.Summary)
+		}
+		if len(opt.Description) > 0 {
+			details = append(details, opt.Description)
+		}
+		if len(opt.EnvVars) > 0 {
+			details = append(details, "EnvVar: "+strings.Join(opt.EnvVars, ", "))
+		}
+		if len(opt.DefaultValues) > 0 {
+			details = append(details, "Default: "+strings.Join(opt.DefaultValues, ", "))
+		}
+
+		entries = append(entries, &helpEntry{term, details})
+	}
+
+	f.outputEntries(w, title, entries)
+}
+
+func (f *ColumnHelpFormatter) OutputPositionals(w io.Writer, title string, positionals []*Positional) {
+	entries := make([]*helpEntry, len(positionals))
+	for i, p := range positionals {
+		entries[i] = &helpEntry{term: p.usage()}
+		if len(p.Summary) > 0 {
+			entries[i].details = []string{p.Summary}
+		}
+	}
+
+	f.outputEntries(w, title, entries)
+}
+
+func (f *ColumnHelpFormatter) OutputCommands(w io.Writer, title string, commands []*Command) {
+	entries := make([]*helpEntry, len(commands))
+	for i, cmd := range commands {
+		entries[i] = &helpEntry{term: strings.Join(cmd.names, ", ")}
+		if len(cmd.summary) > 0 {
+			entries[i].details = []string{cmd.summary}
+		}
+	}
+
+	f.outputEntries(w, title, entries)
+}
+
+// =============================
+// set formatter
+// =============================
+
+func (s *OptionSet) SetHelpFormatter(f HelpFormatter) {
+	s.helpFormatter = f
+}
+
+func (s *OptionSet) getHelpFormatter() HelpFormatter {
+	if s.helpFormatter != nil {
+		return s.helpFormatter
+	}
+	return DefaultHelpFormatter
+}
+
+func (c *Command) SetHelpFormatter(f HelpFormatter) {
+	c.helpFormatter = f
+}
+
+func (c *Command) getHelpFormatter() HelpFormatter {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if cmd.helpFormatter != nil {
+			return cmd.helpFormatter
+		}
+	}
+	return c.options.getHelpFormatter()
+}
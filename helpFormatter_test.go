@@ -0,0 +1,78 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func getHelpFormatterCommand() *Command {
+	cmd := NewSimpleCommand("app", "an app")
+	cmd.options.Add(Option{
+		Key:              "output",
+		Flags:            NewSimpleFlags([]string{"-o", "--output"}),
+		AcceptValue:      true,
+		ValuePlaceholder: "file",
+		EnvVars:          []string{"APP_OUTPUT"},
+		Summary:          "write result to the file instead of standard output",
+	})
+	cmd.options.Add(Option{
+		Key:     "verbose",
+		Flags:   NewSimpleFlags([]string{"-v"}),
+		Summary: "verbose",
+	})
+	cmd.options.AddPositional(Positional{Name: "input", Summary: "input file"})
+	cmd.NewSimpleSubCommand("init", "initialize", "i")
+	return cmd
+}
+
+func TestDefaultHelpFormatter(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	getHelpFormatterCommand().OutputHelp(buffer)
+
+	expect := "\nOptions:\n\n" +
+		"-o|--output <file>\nEnvVar: APP_OUTPUT\nwrite result to the file instead of standard output\n\n" +
+		"-v\nverbose\n\n" +
+		"\nArguments:\n\n[input]\ninput file\n\n" +
+		"\nSub commands:\n\ninit\ninitialize\n\n"
+	if !strings.HasSuffix(buffer.String(), expect) {
+		t.Error(buffer.String())
+	}
+}
+
+func TestColumnHelpFormatter(t *testing.T) {
+	cmd := getHelpFormatterCommand()
+	cmd.SetHelpFormatter(NewColumnHelpFormatter(50))
+	cmdInit := cmd.GetSubCommand("init")
+	cmdInit.options.Add(Option{Key: "long", Flags: NewSimpleFlags([]string{"--a-very-long-flag-name-for-test"}), Summary: "long"})
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputHelp(buffer)
+	expect := "\nOptions:\n\n" +
+		"  -o, --output <file>  write result to the file\n" +
+		"                       instead of standard output\n" +
+		"                       EnvVar: APP_OUTPUT\n" +
+		"  -v                   verbose\n" +
+		"\nArguments:\n\n  [input]  input file\n" +
+		"\nSub commands:\n\n  init, i  initialize\n"
+	if !strings.HasSuffix(buffer.String(), expect) {
+		t.Error(buffer.String())
+	}
+
+	// inherited from parent command
+	buffer.Reset()
+	cmdInit.OutputHelp(buffer)
+	if !strings.HasSuffix(buffer.String(), "\n  --a-very-long-flag-name-for-test\n    long\n") {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	cmd.options.SetHelpFormatter(&ColumnHelpFormatter{Gap: 1, MaxTermWidth: 20})
+	cmd.options.OutputHelp(buffer)
+	expect = "-o, --output <file> write result to the file instead of standard output\n" +
+		"                    EnvVar: APP_OUTPUT\n" +
+		"-v                  verbose\n"
+	if buffer.String() != expect {
+		t.Error(buffer.String())
+	}
+}
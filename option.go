@@ -99,6 +99,29 @@ func NewFlagsValuesOption(key string, flags []string, envVar string, defaultValu
 	}
 }
 
+func (opt *Option) flagNames() []string {
+	names := make([]string, len(opt.Flags))
+	for i, flag := range opt.Flags {
+		names[i] = flag.Name
+	}
+	return names
+}
+
+func (opt *Option) valueUsage() string {
+	if !opt.AcceptValue {
+		return ""
+	}
+
+	placeholder := opt.ValuePlaceholder
+	if len(placeholder) == 0 {
+		placeholder = "value"
+	}
+	if opt.MultiValues {
+		return "<" + placeholder + "> ..."
+	}
+	return "<" + placeholder + ">"
+}
+
 func (opt *Option) OutputHelp(w io.Writer) {
 	if opt.Hidden {
 		return
@@ -106,18 +129,14 @@ func (opt *Option) OutputHelp(w io.Writer) {
 
 	newline := []byte{'\n'}
 
-	for i, flag := range opt.Flags {
-		if i > 0 {
-			w.Write([]byte{'|'})
-		}
-		io.WriteString(w, flag.Name)
-	}
+	io.WriteString(w, strings.Join(opt.flagNames(), "|"))
 
 	if opt.AcceptValue {
-		io.WriteString(w, " <value>")
-		if opt.MultiValues {
-			io.WriteString(w, " ...")
-		}
+		io.WriteString(w, " "+opt.valueUsage())
+	}
+
+	if opt.Required {
+		io.WriteString(w, " (required)")
 	}
 
 	w.Write(newline)
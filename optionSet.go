@@ -27,11 +27,48 @@ func NewOptionSet(
 		flagOptionMap: map[string]*Option{},
 		nameFlagMap:   map[string]*Flag{},
 		keyEnvMap:     map[string][]string{},
+		keyEnvVarMap:  map[string]string{},
 		keyDefaultMap: map[string][]string{},
 	}
 	return s
 }
 
+func (s *OptionSet) clone() *OptionSet {
+	cloned := NewOptionSet(s.mergeFlagPrefix, s.restsSigns, s.groupSeps, s.assignSigns, s.undefFlagPrefixes)
+
+	cloned.options = append(cloned.options, s.options...)
+
+	cloned.hasCanMerge = s.hasCanMerge
+	cloned.hasCanConcatAssign = s.hasCanConcatAssign
+	cloned.hasPrefixMatch = s.hasPrefixMatch
+
+	for k, v := range s.keyOptionMap {
+		cloned.keyOptionMap[k] = v
+	}
+	for k, v := range s.flagOptionMap {
+		cloned.flagOptionMap[k] = v
+	}
+	for k, v := range s.nameFlagMap {
+		cloned.nameFlagMap[k] = v
+	}
+	for k, v := range s.keyEnvMap {
+		cloned.keyEnvMap[k] = v
+	}
+	for k, v := range s.keyEnvVarMap {
+		cloned.keyEnvVarMap[k] = v
+	}
+	for k, v := range s.keyDefaultMap {
+		cloned.keyDefaultMap[k] = v
+	}
+
+	cloned.constraints = s.constraints
+	cloned.positionals = s.positionals
+	cloned.groups = s.groups
+	cloned.helpFormatter = s.helpFormatter
+
+	return cloned
+}
+
 func (s *OptionSet) MergeFlagPrefix() string {
 	return s.mergeFlagPrefix
 }
@@ -108,8 +145,34 @@ func (s *OptionSet) findFlagByPrefix(prefix string) (flag *Flag, ambiguous bool)
 	return matched, false
 }
 
-func (s *OptionSet) Add(opt Option) error {
-	// verify
+func (s *OptionSet) visibleFlagNames() []string {
+	names := []string{}
+	for _, opt := range s.options {
+		if opt.Hidden {
+			continue
+		}
+		for _, flag := range opt.Flags {
+			names = append(names, flag.Name)
+		}
+	}
+	return names
+}
+
+func (s *OptionSet) getFlagSuggestions(kind ParseErrorKind, arg string) []string {
+	if kind == AmbiguousFlagError {
+		suggestions := []string{}
+		for _, name := range s.visibleFlagNames() {
+			if strings.HasPrefix(name, arg) {
+				suggestions = append(suggestions, name)
+			}
+		}
+		return suggestions
+	}
+
+	return getSuggestions(arg, s.visibleFlagNames())
+}
+
+func (s *OptionSet) verify(opt *Option) error {
 	if len(opt.Key) == 0 {
 		return errors.New("key is empty")
 	}
@@ -127,6 +190,14 @@ func (s *OptionSet) Add(opt Option) error {
 		}
 	}
 
+	return nil
+}
+
+func (s *OptionSet) Add(opt Option) error {
+	if err := s.verify(&opt); err != nil {
+		return err
+	}
+
 	if !opt.AcceptValue && len(opt.DefaultValues) > 0 {
 		opt.DefaultValues = nil
 	}
@@ -173,6 +244,7 @@ func (s *OptionSet) Add(opt Option) error {
 		} else {
 			s.keyEnvMap[option.Key] = []string{}
 		}
+		s.keyEnvVarMap[option.Key] = envVar
 	}
 
 	// redundant - default maps
@@ -207,11 +279,5 @@ func (s *OptionSet) AddFlagsValues(key string, flags []string, envVar string, de
 }
 
 func (s *OptionSet) OutputHelp(w io.Writer) {
-	newline := []byte{'\n'}
-	for _, opt := range s.options {
-		if !opt.Hidden {
-			opt.OutputHelp(w)
-			w.Write(newline)
-		}
-	}
+	s.outputHelp(w, false)
 }
@@ -0,0 +1,211 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"strings"
+)
+
+type ConstraintKind int
+
+const (
+	ExclusiveConstraint ConstraintKind = iota
+	RequiresConstraint
+	RequiresAnyConstraint
+	ConflictsConstraint
+)
+
+type constraint struct {
+	kind ConstraintKind
+	key  string
+	keys []string
+}
+
+func (s *OptionSet) isInheritedBy(c *constraint, own, effective *OptionSet) bool {
+	keys := c.keys
+	if len(c.key) > 0 {
+		keys = append([]string{c.key}, keys...)
+	}
+
+	for _, key := range keys {
+		opt := s.keyOptionMap[key]
+		if opt == nil || !opt.Persistent || own.keyOptionMap[key] != nil || effective.keyOptionMap[key] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+type ConstraintViolation struct {
+	Kind  ConstraintKind
+	Key   string
+	Flag  string
+	Keys  []string
+	Flags []string
+}
+
+func (v *ConstraintViolation) Error() string {
+	flags := strings.Join(v.Flags, ", ")
+
+	switch v.Kind {
+	case ExclusiveConstraint:
+		return "only one of " + flags + " can be specified"
+	case RequiresConstraint:
+		return v.Flag + " requires " + flags
+	case RequiresAnyConstraint:
+		return v.Flag + " requires one of " + flags
+	case ConflictsConstraint:
+		return v.Flag + " conflicts with " + flags
+	default:
+		return "constraint violated"
+	}
+}
+
+// =============================
+// define constraints
+// =============================
+
+func (s *OptionSet) addConstraint(kind ConstraintKind, key string, keys []string) error {
+	if len(key) > 0 && s.keyOptionMap[key] == nil {
+		return errors.New("key '" + key + "' not exists")
+	}
+	if len(keys) == 0 {
+		return errors.New("constraint keys are empty")
+	}
+	for _, k := range keys {
+		if s.keyOptionMap[k] == nil {
+			return errors.New("key '" + k + "' not exists")
+		}
+	}
+
+	s.constraints = append(s.constraints, &constraint{
+		kind: kind,
+		key:  key,
+		keys: copys(keys),
+	})
+	return nil
+}
+
+func (s *OptionSet) AddExclusive(keys ...string) error {
+	return s.addConstraint(ExclusiveConstraint, "", keys)
+}
+
+func (s *OptionSet) AddRequires(key string, requiredKeys ...string) error {
+	return s.addConstraint(RequiresConstraint, key, requiredKeys)
+}
+
+func (s *OptionSet) AddRequiresAny(key string, anyKeys ...string) error {
+	return s.addConstraint(RequiresAnyConstraint, key, anyKeys)
+}
+
+func (s *OptionSet) AddConflicts(key string, conflictKeys ...string) error {
+	return s.addConstraint(ConflictsConstraint, key, conflictKeys)
+}
+
+func (s *OptionSet) exclusiveKeys(key string) []string {
+	keys := []string{}
+
+	for _, c := range s.constraints {
+		switch c.kind {
+		case ExclusiveConstraint:
+			if contains(c.keys, key) {
+				for _, k := range c.keys {
+					if k != key {
+						keys = appendUnique(keys, k)
+					}
+				}
+			}
+		case ConflictsConstraint:
+			if c.key == key {
+				keys = appendUnique(keys, c.keys...)
+			} else if contains(c.keys, key) {
+				keys = appendUnique(keys, c.key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// =============================
+// check constraints
+// =============================
+
+func (r *ParseResult) isKeySet(key string) bool {
+	return r.HasFlagKey(key) || r.HasEnvKey(key) || r.HasConfigKey(key)
+}
+
+func (r *ParseResult) flagNameOf(key string) string {
+	if flag, found := r.specifiedFlags[key]; found {
+		return flag
+	}
+	if flag, found := r.configFlags[key]; found {
+		return flag
+	}
+	if opt := r.keyOptionMap[key]; opt != nil {
+		return opt.displayName()
+	}
+	return key
+}
+
+func (r *ParseResult) newViolation(c *constraint, keys []string) *ConstraintViolation {
+	violation := &ConstraintViolation{
+		Kind:  c.kind,
+		Key:   c.key,
+		Keys:  keys,
+		Flags: make([]string, len(keys)),
+	}
+	if len(c.key) > 0 {
+		violation.Flag = r.flagNameOf(c.key)
+	}
+	for i, key := range keys {
+		violation.Flags[i] = r.flagNameOf(key)
+	}
+	return violation
+}
+
+func (r *ParseResult) checkConstraint(c *constraint) *ConstraintViolation {
+	if c.kind != ExclusiveConstraint && !r.isKeySet(c.key) {
+		return nil
+	}
+
+	setKeys := []string{}
+	unsetKeys := []string{}
+	for _, key := range c.keys {
+		if r.isKeySet(key) {
+			setKeys = append(setKeys, key)
+		} else {
+			unsetKeys = append(unsetKeys, key)
+		}
+	}
+
+	switch c.kind {
+	case ExclusiveConstraint:
+		if len(setKeys) > 1 {
+			return r.newViolation(c, setKeys)
+		}
+	case RequiresConstraint:
+		if len(unsetKeys) > 0 {
+			return r.newViolation(c, unsetKeys)
+		}
+	case RequiresAnyConstraint:
+		if len(setKeys) == 0 {
+			return r.newViolation(c, c.keys)
+		}
+	case ConflictsConstraint:
+		if len(setKeys) > 0 {
+			return r.newViolation(c, setKeys)
+		}
+	}
+
+	return nil
+}
+
+func (r *ParseResult) GetViolations() []*ConstraintViolation {
+	violations := []*ConstraintViolation{}
+	for _, c := range r.constraints {
+		if violation := r.checkConstraint(c); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,99 @@
+package goNixArgParser
+
+import (
+	"testing"
+)
+
+func getConstraintOptionSet(t *testing.T) *OptionSet {
+	s := NewSimpleOptionSet()
+	s.AddFlags("json", []string{"-j", "--json"}, "", "")
+	s.AddFlag("yaml", "--yaml", "", "")
+	s.AddFlag("table", "--table", "", "")
+	s.AddFlagValue("tlsCert", "--tls-cert", "", "", "")
+	s.AddFlagValue("tlsKey", "--tls-key", "", "", "")
+	s.AddFlag("quiet", "-q", "", "")
+	s.AddFlag("verbose", "-v", "", "")
+	s.AddFlagValue("output", "--output", "", "", "")
+
+	if err := s.AddExclusive("json", "yaml", "table"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddRequires("tlsCert", "tlsKey"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddRequiresAny("output", "json", "yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddConflicts("quiet", "verbose"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddConflicts("quiet", "no-such-key"); err == nil {
+		t.Error("should fail on unknown key")
+	}
+
+	return s
+}
+
+func TestConstraints(t *testing.T) {
+	s := getConstraintOptionSet(t)
+
+	r := s.Parse([]string{"-j", "--table", "--tls-cert", "cert.pem", "--output", "a.txt", "-qv"}, nil)
+	violations := r.GetViolations()
+	if len(violations) != 3 {
+		t.Fatal(violations)
+	}
+
+	if violations[0].Kind != ExclusiveConstraint || !expectStrings(violations[0].Flags, "-j", "--table") {
+		t.Error(violations[0])
+	}
+	if violations[0].Error() != "only one of -j, --table can be specified" {
+		t.Error(violations[0].Error())
+	}
+
+	if violations[1].Kind != RequiresConstraint || violations[1].Flag != "--tls-cert" || !expectStrings(violations[1].Keys, "tlsKey") {
+		t.Error(violations[1])
+	}
+
+	if violations[2].Kind != ConflictsConstraint || violations[2].Flag != "-q" || !expectStrings(violations[2].Flags, "-v") {
+		t.Error(violations[2])
+	}
+
+	r = s.Parse([]string{"--output", "a.txt"}, nil)
+	violations = r.GetViolations()
+	if len(violations) != 1 || violations[0].Kind != RequiresAnyConstraint {
+		t.Fatal(violations)
+	}
+	if violations[0].Error() != "--output requires one of -j, --yaml" {
+		t.Error(violations[0].Error())
+	}
+	if r.Validate() == nil {
+		t.Error("validate should fail")
+	}
+
+	r = s.Parse([]string{"--output", "a.txt", "--yaml", "--tls-cert", "c", "--tls-key", "k"}, nil)
+	if err := r.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConstraintTypedFlags(t *testing.T) {
+	s := NewSimpleOptionSet()
+	s.Add(Option{Key: "quiet", Flags: []*Flag{NewFlag("--quiet", 3, false, false, false), NewFlag("-q", 0, true, false, false)}})
+	s.Add(Option{Key: "verbose", Flags: []*Flag{NewFlag("--verbose", 3, false, false, false), NewFlag("-v", 0, true, false, false)}})
+	s.Add(Option{Key: "level", Flags: []*Flag{NewFlag("--level", 3, false, true, false)}, AcceptValue: true})
+	s.AddConflicts("quiet", "verbose", "level")
+
+	r := s.Parse([]string{"--qu", "--verb"}, nil)
+	if violations := r.GetViolations(); len(violations) != 1 || violations[0].Error() != "--qu conflicts with --verb" {
+		t.Error(violations)
+	}
+
+	r = s.Parse([]string{"-qv", "--lev=1"}, nil)
+	if violations := r.GetViolations(); len(violations) != 1 || violations[0].Error() != "-q conflicts with -v, --lev" {
+		t.Error(violations)
+	}
+
+	if p := r.GetProvenance("level"); p.Flag != "--lev" || p.Values[0] != "1" {
+		t.Error(p)
+	}
+}
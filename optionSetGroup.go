@@ -0,0 +1,129 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"io"
+)
+
+type optionGroup struct {
+	name     string
+	advanced bool
+}
+
+type helpSection struct {
+	title   string
+	options []*Option
+}
+
+// =============================
+// define groups
+// =============================
+
+func (s *OptionSet) getGroup(name string) *optionGroup {
+	for _, group := range s.groups {
+		if group.name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+func (s *OptionSet) addGroup(name string, advanced bool, keys []string) error {
+	if len(name) == 0 {
+		return errors.New("group name is empty")
+	}
+
+	group := s.getGroup(name)
+	if group != nil && group.advanced != advanced {
+		return errors.New("group '" + name + "' already exists")
+	}
+	if group == nil {
+		group = &optionGroup{name: name, advanced: advanced}
+	}
+
+	for _, key := range keys {
+		opt := s.keyOptionMap[key]
+		if opt == nil {
+			return errors.New("key '" + key + "' not exists")
+		}
+		if opt.group != nil && opt.group != group {
+			return errors.New("key '" + key + "' already in group '" + opt.group.name + "'")
+		}
+	}
+
+	if s.getGroup(name) == nil {
+		s.groups = append(s.groups, group)
+	}
+	for _, key := range keys {
+		s.keyOptionMap[key].group = group
+	}
+
+	return nil
+}
+
+func (s *OptionSet) AddGroup(name string, keys ...string) error {
+	return s.addGroup(name, false, keys)
+}
+
+func (s *OptionSet) AddAdvancedGroup(name string, keys ...string) error {
+	return s.addGroup(name, true, keys)
+}
+
+func (s *OptionSet) Groups() []string {
+	names := make([]string, len(s.groups))
+	for i, group := range s.groups {
+		names[i] = group.name
+	}
+	return names
+}
+
+// =============================
+// output groups
+// =============================
+
+func (opt *Option) isCollapsed(all bool) bool {
+	return opt.Hidden || (!all && opt.group != nil && opt.group.advanced)
+}
+
+func (s *OptionSet) helpSections(title string, all bool) (sections []*helpSection, collapsed []string) {
+	ungrouped := &helpSection{title: title, options: []*Option{}}
+	for _, opt := range s.options {
+		if opt.group == nil && !opt.isCollapsed(all) {
+			ungrouped.options = append(ungrouped.options, opt)
+		}
+	}
+	if len(ungrouped.options) > 0 || len(s.groups) == 0 {
+		sections = append(sections, ungrouped)
+	}
+
+	for _, group := range s.groups {
+		if group.advanced && !all {
+			collapsed = append(collapsed, group.name)
+			continue
+		}
+
+		section := &helpSection{title: group.name}
+		for _, opt := range s.options {
+			if opt.group == group && !opt.Hidden {
+				section.options = append(section.options, opt)
+			}
+		}
+		if len(section.options) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	return
+}
+
+func (s *OptionSet) outputHelp(w io.Writer, all bool) {
+	formatter := s.getHelpFormatter()
+	sections, _ := s.helpSections("", all)
+	for _, section := range sections {
+		formatter.OutputOptions(w, section.title, section.options)
+	}
+}
+
+func (s *OptionSet) OutputHelpAll(w io.Writer) {
+	s.outputHelp(w, true)
+}
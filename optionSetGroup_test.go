@@ -0,0 +1,109 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func getGroupCommand() *Command {
+	cmd := NewSimpleCommand("app", "")
+	cmd.EnableHelp()
+	cmd.EnableHelpAll()
+
+	s := cmd.options
+	s.AddFlagValue("host", "--host", "", "", "listen host")
+	s.AddFlagValue("cert", "--cert", "", "", "certificate file")
+	s.AddFlagValue("key", "--key", "", "", "key file")
+	s.AddFlag("debug", "--debug", "", "debug mode")
+	s.AddFlagValue("logfile", "--log-file", "", "", "log file")
+	s.Add(Option{Key: "trace", Flags: NewSimpleFlags([]string{"--trace"}), Hidden: true, Persistent: true})
+	s.Add(Option{Key: "level", Flags: NewSimpleFlags([]string{"--level"}), Persistent: true, Summary: "log level"})
+
+	s.AddGroup("Network", "host")
+	s.AddGroup("TLS", "cert", "key")
+	s.AddAdvancedGroup("Logging", "logfile", "level", "trace")
+	s.AddGroup("Network", "debug")
+
+	cmd.NewSimpleSubCommand("serve", "")
+	return cmd
+}
+
+func TestAddGroup(t *testing.T) {
+	cmd := getGroupCommand()
+	s := cmd.options
+
+	groups := s.Groups()
+	if len(groups) != 3 || groups[0] != "Network" || groups[1] != "TLS" || groups[2] != "Logging" {
+		t.Error(groups)
+	}
+
+	if err := s.AddGroup("TLS", "host"); err == nil {
+		t.Error("should fail for key in another group")
+	}
+	if err := s.AddGroup("Other", "missing"); err == nil {
+		t.Error("should fail for missing key")
+	}
+	if err := s.AddAdvancedGroup("TLS"); err == nil {
+		t.Error("should fail for group with different kind")
+	}
+	if err := s.AddGroup(""); err == nil {
+		t.Error("should fail for empty name")
+	}
+}
+
+func TestOutputGroupHelp(t *testing.T) {
+	cmd := getGroupCommand()
+
+	buffer := &bytes.Buffer{}
+	cmd.OutputHelp(buffer)
+	help := buffer.String()
+
+	expect := "\nOptions:\n\n-h|--help\nshow help\n\n--help-all\nshow help of all options\n\n" +
+		"\nNetwork:\n\n--host <value>\nlisten host\n\n--debug\ndebug mode\n\n" +
+		"\nTLS:\n\n--cert <value>\ncertificate file\n\n--key <value>\nkey file\n\n"
+	if !strings.Contains(help, expect) {
+		t.Error(help)
+	}
+	if strings.Contains(help, "--log-file") || strings.Contains(help, "--trace") {
+		t.Error("advanced group and hidden option should be collapsed")
+	}
+	if !strings.HasSuffix(help, "\nUse --help-all to show all options, including: Logging\n") {
+		t.Error(help)
+	}
+
+	buffer.Reset()
+	cmd.OutputHelpAll(buffer)
+	help = buffer.String()
+	if !strings.Contains(help, "\nLogging:\n\n--log-file <value>\nlog file\n\n--level\nlog level\n\n") ||
+		strings.Contains(help, "--trace") || strings.Contains(help, "Use --help-all") {
+		t.Error(help)
+	}
+
+	// global options in advanced group
+	result := cmd.Parse([]string{"app", "serve", "--help"}, nil)
+	if !result.HelpRequested() || result.HelpAllRequested() {
+		t.Error("help")
+	}
+	buffer.Reset()
+	result.OutputHelp(buffer)
+	if strings.Contains(buffer.String(), "--level") {
+		t.Error(buffer.String())
+	}
+
+	result = cmd.Parse([]string{"app", "serve", "--help-all"}, nil)
+	if !result.HelpRequested() || !result.HelpAllRequested() {
+		t.Error("help all")
+	}
+	buffer.Reset()
+	result.OutputHelp(buffer)
+	if !strings.Contains(buffer.String(), "\nGlobal options:\n\n--level\nlog level\n") {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	cmd.options.OutputHelp(buffer)
+	if !strings.HasPrefix(buffer.String(), "-h|--help\n") || !strings.Contains(buffer.String(), "\nTLS:\n\n") {
+		t.Error(buffer.String())
+	}
+}
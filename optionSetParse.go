@@ -30,7 +30,7 @@ func (s *OptionSet) splitMergedToken(token *argToken) (results []*argToken, succ
 			if !flag.canMerge {
 				return
 			}
-			splittedTokens = append(splittedTokens, newToken(splittedArg, flagArg))
+			splittedTokens = append(splittedTokens, token.newSplitToken(splittedArg, flagArg, splittedArg))
 			prevFlag = flag
 			continue
 		}
@@ -45,7 +45,7 @@ func (s *OptionSet) splitMergedToken(token *argToken) (results []*argToken, succ
 		}
 
 		// re-generate standalone flag with values
-		splittedTokens[len(splittedTokens)-1] = newToken(prevFlag.Name+mergedArgs[i:], undetermArg)
+		splittedTokens[len(splittedTokens)-1] = token.newSplitToken(prevFlag.Name+mergedArgs[i:], undetermArg, prevFlag.Name+mergedArgs[i:])
 		break
 	}
 
@@ -82,8 +82,8 @@ func (s *OptionSet) splitAssignSignToken(token *argToken) (results []*argToken)
 			prefix := flagName + assignSign
 			if strings.HasPrefix(text, prefix) {
 				results = append(results,
-					newToken(flagName, flagArg),
-					newToken(text[len(prefix):], valueArg),
+					token.newSplitToken(flagName, flagArg, flagName),
+					newToken(text[len(prefix):], valueArg, token.index),
 				)
 				return
 			}
@@ -95,8 +95,8 @@ func (s *OptionSet) splitAssignSignToken(token *argToken) (results []*argToken)
 			prefix = text[0:assignIndex]
 			if foundFlag, _ := s.findFlagByPrefix(prefix); foundFlag == flag {
 				results = append(results,
-					newToken(flagName, flagArg),
-					newToken(text[assignIndex+len(assignSign):], valueArg),
+					token.newSplitToken(flagName, flagArg, prefix),
+					newToken(text[assignIndex+len(assignSign):], valueArg, token.index),
 				)
 				return
 			}
@@ -135,8 +135,8 @@ func (s *OptionSet) splitConcatAssignToken(token *argToken) (results []*argToken
 		flagName := flag.Name
 		flagValue := text[len(flagName):]
 		results = append(results,
-			newToken(flagName, flagArg),
-			newToken(flagValue, valueArg),
+			token.newSplitToken(flagName, flagArg, flagName),
+			newToken(flagValue, valueArg, token.index),
 		)
 		return
 	}
@@ -210,15 +210,7 @@ func isValueToken(flag *Flag, token *argToken) bool {
 	}
 }
 
-func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (options map[string][]string, rests, ambigus, undefs []string) {
-	options = map[string][]string{}
-	rests = []string{}
-	ambigus = []string{}
-	undefs = []string{}
-
-	flagOptionMap := s.flagOptionMap
-	flagMap := s.nameFlagMap
-
+func (s *OptionSet) prepareTokens(tokens []*argToken) []*argToken {
 	if s.hasCanMerge {
 		tokens = s.splitMergedTokens(tokens)
 	}
@@ -232,7 +224,34 @@ func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (options map[string][
 	s.markAmbiguPrefixTokens(tokens)
 	s.markUndefTokens(tokens)
 
-	// walk
+	return tokens
+}
+
+func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (
+	options map[string][]string,
+	flags map[string]string,
+	rests, ambigus, undefs []string,
+	parseErrors []*ParseError,
+) {
+	return s.walkTokens(s.prepareTokens(tokens))
+}
+
+func (s *OptionSet) walkTokens(tokens []*argToken) (
+	options map[string][]string,
+	flags map[string]string,
+	rests, ambigus, undefs []string,
+	parseErrors []*ParseError,
+) {
+	options = map[string][]string{}
+	flags = map[string]string{}
+	rests = []string{}
+	ambigus = []string{}
+	undefs = []string{}
+	parseErrors = []*ParseError{}
+
+	flagOptionMap := s.flagOptionMap
+	flagMap := s.nameFlagMap
+
 	for i, tokenCount, peeked := 0, len(tokens), 0; i < tokenCount; i, peeked = i+1+peeked, 0 {
 		token := tokens[i]
 
@@ -256,6 +275,7 @@ func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (options map[string][
 
 		if token.kind == ambiguousFlagArg {
 			ambigus = append(ambigus, token.text)
+			parseErrors = append(parseErrors, s.newParseError(AmbiguousFlagError, token))
 			continue
 		}
 
@@ -266,12 +286,14 @@ func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (options map[string][
 
 		if token.kind == undefFlagArg {
 			undefs = append(undefs, token.text)
+			parseErrors = append(parseErrors, s.newParseError(UndefFlagError, token))
 			continue
 		}
 
 		// normal
 		opt := flagOptionMap[token.text]
 		flag := flagMap[token.text]
+		flags[opt.Key] = token.raw
 
 		if !opt.AcceptValue { // option has no value
 			options[opt.Key] = []string{}
@@ -330,18 +352,28 @@ func (s *OptionSet) parseTokensInGroup(tokens []*argToken) (options map[string][
 		}
 	}
 
-	return options, rests, ambigus, undefs
+	return options, flags, rests, ambigus, undefs, parseErrors
 }
 
-func (s *OptionSet) parseInGroup(specifiedTokens, configTokens []*argToken) *ParseResult {
+func (s *OptionSet) parseInGroup(groupIndex int, specifiedTokens, configTokens []*argToken) *ParseResult {
 	keyOptionMap := s.keyOptionMap
 
-	specifiedOptions, specifiedRests, specifiedAmbigus, specifiedUndefs := s.parseTokensInGroup(specifiedTokens)
+	specifiedOptions, specifiedFlags, specifiedRests, specifiedAmbigus, specifiedUndefs, specifiedErrors := s.parseTokensInGroup(specifiedTokens)
 	envs := s.keyEnvMap
-	configOptions, configRests, configAmbigus, configUndefs := s.parseTokensInGroup(configTokens)
+	configOptions, configFlags, configRests, configAmbigus, configUndefs, configErrors := s.parseTokensInGroup(configTokens)
 	defaults := s.keyDefaultMap
 
-	return &ParseResult{
+	for _, parseError := range specifiedErrors {
+		parseError.GroupIndex = groupIndex
+		parseError.Source = FlagSource
+	}
+	for _, parseError := range configErrors {
+		parseError.GroupIndex = groupIndex
+		parseError.Source = ConfigSource
+	}
+
+	result := &ParseResult{
+		options:      s.options,
 		keyOptionMap: keyOptionMap,
 
 		specifiedOptions: specifiedOptions,
@@ -349,6 +381,14 @@ func (s *OptionSet) parseInGroup(specifiedTokens, configTokens []*argToken) *Par
 		configOptions:    configOptions,
 		defaults:         defaults,
 
+		specifiedFlags: specifiedFlags,
+		envVars:        s.keyEnvVarMap,
+		configFlags:    configFlags,
+		configFiles:    map[string]string{},
+		configShadowed: map[string][]ValueOrigin{},
+		constraints:    s.constraints,
+		positionals:    s.positionals,
+
 		specifiedRests: specifiedRests,
 		configRests:    configRests,
 
@@ -357,40 +397,49 @@ func (s *OptionSet) parseInGroup(specifiedTokens, configTokens []*argToken) *Par
 
 		specifiedUndefs: specifiedUndefs,
 		configUndefs:    configUndefs,
+
+		parseErrors: append(specifiedErrors, configErrors...),
+
+		values:      map[string][]interface{}{},
+		valueErrors: map[string]error{},
 	}
+	result.parseValues(s.options)
+
+	return result
 }
 
-func (s *OptionSet) argsToTokensGroups(args []string) (tokensGroups [][]*argToken) {
+func (s *OptionSet) argsToTokensGroups(args []string, offset int) (tokensGroups [][]*argToken) {
 	tokensGroups = make([][]*argToken, 1)
 	groupIndex := 0
 
 	foundRestSign := false
-	for _, arg := range args {
+	for i, arg := range args {
+		index := offset + i
 		switch {
 		case s.isGroupSep(arg):
 			tokensGroups = append(tokensGroups, make([]*argToken, 0, 4))
 			groupIndex++
 			foundRestSign = false
 		case foundRestSign:
-			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, restArg))
+			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, restArg, index))
 		case s.isRestSign(arg):
-			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, restSignArg))
+			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, restSignArg, index))
 			foundRestSign = true
 		case s.nameFlagMap[arg] != nil:
-			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, flagArg))
+			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, flagArg, index))
 		default:
-			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, undetermArg))
+			tokensGroups[groupIndex] = append(tokensGroups[groupIndex], newToken(arg, undetermArg, index))
 		}
 	}
 
 	return
 }
 
-func (s *OptionSet) getAlignedTokensGroups(specifiedArgs, configArgs []string) ([][]*argToken, [][]*argToken) {
-	specifiedTokensGroups := s.argsToTokensGroups(specifiedArgs)
+func (s *OptionSet) getAlignedTokensGroups(specifiedArgs, configArgs []string, specifiedOffset, configOffset int) ([][]*argToken, [][]*argToken) {
+	specifiedTokensGroups := s.argsToTokensGroups(specifiedArgs, specifiedOffset)
 	specifiedTokensGroupsCount := len(specifiedTokensGroups)
 
-	configTokensGroups := s.argsToTokensGroups(configArgs)
+	configTokensGroups := s.argsToTokensGroups(configArgs, configOffset)
 	configTokensGroupsCount := len(configTokensGroups)
 
 	maxCount := specifiedTokensGroupsCount
@@ -409,20 +458,24 @@ func (s *OptionSet) getAlignedTokensGroups(specifiedArgs, configArgs []string) (
 	return specifiedTokensGroups, configTokensGroups
 }
 
-func (s *OptionSet) ParseGroups(specifiedArgs, configArgs []string) []*ParseResult {
-	specifiedTokensGroups, configTokensGroups := s.getAlignedTokensGroups(specifiedArgs, configArgs)
+func (s *OptionSet) parseGroupsAt(specifiedArgs, configArgs []string, specifiedOffset, configOffset int) []*ParseResult {
+	specifiedTokensGroups, configTokensGroups := s.getAlignedTokensGroups(specifiedArgs, configArgs, specifiedOffset, configOffset)
 
 	length := len(specifiedTokensGroups)
 	results := make([]*ParseResult, length)
 	for i := 0; i < length; i++ {
-		results[i] = s.parseInGroup(specifiedTokensGroups[i], configTokensGroups[i])
+		results[i] = s.parseInGroup(i, specifiedTokensGroups[i], configTokensGroups[i])
 	}
 
 	return results
 }
 
-func (s *OptionSet) Parse(specifiedArgs, configArgs []string) *ParseResult {
-	specifiedTokensGroups, configTokensGroups := s.getAlignedTokensGroups(specifiedArgs, configArgs)
+func (s *OptionSet) ParseGroups(specifiedArgs, configArgs []string) []*ParseResult {
+	return s.parseGroupsAt(specifiedArgs, configArgs, 0, 0)
+}
+
+func (s *OptionSet) parseAt(specifiedArgs, configArgs []string, specifiedOffset, configOffset int) *ParseResult {
+	specifiedTokensGroups, configTokensGroups := s.getAlignedTokensGroups(specifiedArgs, configArgs, specifiedOffset, configOffset)
 
 	var specifiedTokens []*argToken
 	if len(specifiedTokensGroups) > 0 {
@@ -438,7 +491,16 @@ func (s *OptionSet) Parse(specifiedArgs, configArgs []string) *ParseResult {
 		configTokens = []*argToken{}
 	}
 
-	result := s.parseInGroup(specifiedTokens, configTokens)
+	result := s.parseInGroup(0, specifiedTokens, configTokens)
 
 	return result
 }
+
+func (s *OptionSet) Parse(specifiedArgs, configArgs []string) *ParseResult {
+	return s.parseAt(specifiedArgs, configArgs, 0, 0)
+}
+
+func (s *OptionSet) ParseStrict(specifiedArgs, configArgs []string) (*ParseResult, error) {
+	result := s.Parse(specifiedArgs, configArgs)
+	return result, ErrorList(result.GetErrors()).orNil()
+}
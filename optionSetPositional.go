@@ -0,0 +1,198 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrUnexpectedArg = errors.New("unexpected argument")
+
+type PositionalError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *PositionalError) Error() string {
+	switch e.Err {
+	case ErrValueAbsent:
+		return "missing argument <" + e.Name + ">"
+	case ErrUnexpectedArg:
+		return "unexpected argument '" + e.Value + "'"
+	default:
+		return "invalid value '" + e.Value + "' for argument <" + e.Name + ">: " + e.Err.Error()
+	}
+}
+
+func (e *PositionalError) Unwrap() error {
+	return e.Err
+}
+
+func (p *Positional) usage() string {
+	usage := p.Name
+	if p.Variadic {
+		usage += "..."
+	}
+	if p.Required {
+		return "<" + usage + ">"
+	}
+	return "[" + usage + "]"
+}
+
+func (p *Positional) OutputHelp(w io.Writer) {
+	newline := []byte{'\n'}
+
+	io.WriteString(w, p.usage())
+	w.Write(newline)
+
+	if len(p.Summary) > 0 {
+		io.WriteString(w, p.Summary)
+		w.Write(newline)
+	}
+}
+
+// =============================
+// define positionals
+// =============================
+
+func (s *OptionSet) AddPositional(p Positional) error {
+	if len(p.Name) == 0 {
+		return errors.New("positional name is empty")
+	}
+
+	for _, prev := range s.positionals {
+		if prev.Name == p.Name {
+			return errors.New("positional '" + p.Name + "' already exists")
+		}
+		if prev.Variadic {
+			return errors.New("positional '" + p.Name + "' follows variadic positional '" + prev.Name + "'")
+		}
+		if p.Required && !prev.Required {
+			return errors.New("required positional '" + p.Name + "' follows optional positional '" + prev.Name + "'")
+		}
+	}
+
+	s.positionals = append(s.positionals, &p)
+	return nil
+}
+
+func (s *OptionSet) Positionals() []Positional {
+	positionals := make([]Positional, len(s.positionals))
+	for i, p := range s.positionals {
+		positionals[i] = *p
+	}
+	return positionals
+}
+
+func (s *OptionSet) OutputPositionalsHelp(w io.Writer) {
+	s.getHelpFormatter().OutputPositionals(w, "", s.positionals)
+}
+
+// =============================
+// get positionals
+// =============================
+
+func (r *ParseResult) getPositionalStrings(name string) (p *Positional, values []string, found bool) {
+	rests := r.GetRests()
+
+	for i, positional := range r.positionals {
+		if positional.Name != name {
+			continue
+		}
+		if i >= len(rests) {
+			return positional, nil, false
+		}
+		if positional.Variadic {
+			return positional, rests[i:], true
+		}
+		return positional, rests[i : i+1], true
+	}
+
+	return nil, nil, false
+}
+
+func (r *ParseResult) GetPositional(name string) (value string, found bool) {
+	_, values, found := r.getPositionalStrings(name)
+	if found {
+		value = values[0]
+	}
+	return
+}
+
+func (r *ParseResult) GetPositionals(name string) (values []string, found bool) {
+	_, values, found = r.getPositionalStrings(name)
+	return
+}
+
+func parsePositional(p *Positional, strs []string) (values []interface{}, err error) {
+	values = make([]interface{}, len(strs))
+	for i, str := range strs {
+		values[i], err = p.Value.Parse(str)
+		if err != nil {
+			return nil, &PositionalError{Name: p.Name, Value: str, Err: err}
+		}
+	}
+	return values, nil
+}
+
+func GetPositionalValue[T any](r *ParseResult, name string) (value T, found bool) {
+	values, found := GetPositionalValues[T](r, name)
+	if found {
+		value = values[0]
+	}
+	return
+}
+
+func GetPositionalValues[T any](r *ParseResult, name string) (values []T, found bool) {
+	p, strs, found := r.getPositionalStrings(name)
+	if !found || p.Value == nil {
+		return nil, false
+	}
+
+	anyValues, err := parsePositional(p, strs)
+	if err != nil {
+		return nil, false
+	}
+
+	values = make([]T, len(anyValues))
+	for i, anyValue := range anyValues {
+		values[i], found = anyValue.(T)
+		if !found {
+			return nil, false
+		}
+	}
+	return
+}
+
+func (r *ParseResult) GetPositionalErrors() []error {
+	errs := []error{}
+	if len(r.positionals) == 0 {
+		return errs
+	}
+
+	rests := r.GetRests()
+	consumed := 0
+
+	for _, p := range r.positionals {
+		_, strs, found := r.getPositionalStrings(p.Name)
+		if !found {
+			if p.Required {
+				errs = append(errs, &PositionalError{Name: p.Name, Err: ErrValueAbsent})
+			}
+			continue
+		}
+		consumed += len(strs)
+
+		if p.Value != nil {
+			if _, err := parsePositional(p, strs); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, extra := range rests[consumed:] {
+		errs = append(errs, &PositionalError{Value: extra, Err: ErrUnexpectedArg})
+	}
+
+	return errs
+}
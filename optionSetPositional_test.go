@@ -0,0 +1,65 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPositionals(t *testing.T) {
+	cmd := NewSimpleCommand("git", "")
+	cmdAdd := cmd.NewSimpleSubCommand("remote", "").NewSimpleSubCommand("add", "")
+	opts := cmdAdd.Options()
+	opts.AddFlag("fetch", "-f", "", "")
+
+	if err := opts.AddPositional(Positional{Name: "name", Summary: "remote name", Required: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.AddPositional(Positional{Name: "url", Required: true, Value: URLValue}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.AddPositional(Positional{Name: "extras", Variadic: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.AddPositional(Positional{Name: "more"}); err == nil {
+		t.Error("should not add positional after variadic")
+	}
+
+	r := cmd.Parse([]string{"git", "remote", "add", "-f", "origin", "https://example.com/repo.git", "a", "b"}, nil)
+	if name, _ := r.GetPositional("name"); name != "origin" {
+		t.Error(name)
+	}
+	if u, found := GetPositionalValue[interface{ Hostname() string }](r, "url"); !found || u.Hostname() != "example.com" {
+		t.Error(u, found)
+	}
+	if extras, _ := r.GetPositionals("extras"); !expectStrings(extras, "a", "b") {
+		t.Error(extras)
+	}
+	if err := r.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	r = cmd.Parse([]string{"git", "remote", "add", "origin"}, nil)
+	errs := r.GetPositionalErrors()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrValueAbsent) || errs[0].Error() != "missing argument <url>" {
+		t.Error(errs)
+	}
+
+	buffer := &bytes.Buffer{}
+	cmdAdd.OutputHelp(buffer)
+	if !strings.Contains(buffer.String(), "<name>\nremote name\n") || !strings.Contains(buffer.String(), "[extras...]") {
+		t.Error(buffer.String())
+	}
+}
+
+func TestPositionalsUnexpected(t *testing.T) {
+	s := NewSimpleOptionSet()
+	s.AddPositional(Positional{Name: "file"})
+
+	r := s.Parse([]string{"a", "b"}, nil)
+	errs := r.GetPositionalErrors()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrUnexpectedArg) {
+		t.Error(errs)
+	}
+}
@@ -0,0 +1,163 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	flagsTagName       = "flags"
+	envTagName         = "env"
+	defaultTagName     = "default"
+	summaryTagName     = "summary"
+	descTagName        = "desc"
+	delimsTagName      = "delims"
+	hiddenTagName      = "hidden"
+	requiredTagName    = "required"
+	persistentTagName  = "persistent"
+	placeholderTagName = "placeholder"
+	secretTagName      = "secret"
+)
+
+func splitTagList(tag string) []string {
+	if len(tag) == 0 {
+		return nil
+	}
+
+	items := strings.Split(tag, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return removeEmptyInplace(items)
+}
+
+func newStructFieldOption(key string, field reflect.StructField) (opt Option, err error) {
+	tag := field.Tag
+
+	flagNames := splitTagList(tag.Get(flagsTagName))
+	if len(flagNames) == 0 {
+		flagNames = []string{"--" + key}
+	}
+
+	opt = Option{
+		Key:         key,
+		Summary:     tag.Get(summaryTagName),
+		Description: tag.Get(descTagName),
+		Flags:       NewSimpleFlags(flagNames),
+		EnvVars:     splitTagList(tag.Get(envTagName)),
+		Delimiters:  []rune(tag.Get(delimsTagName)),
+
+		ValuePlaceholder: tag.Get(placeholderTagName),
+	}
+
+	if hidden := tag.Get(hiddenTagName); len(hidden) > 0 {
+		opt.Hidden, err = strconv.ParseBool(hidden)
+		if err != nil {
+			return opt, errors.New("key '" + key + "': invalid hidden tag '" + hidden + "'")
+		}
+	}
+
+	if required := tag.Get(requiredTagName); len(required) > 0 {
+		opt.Required, err = strconv.ParseBool(required)
+		if err != nil {
+			return opt, errors.New("key '" + key + "': invalid required tag '" + required + "'")
+		}
+	}
+
+	if persistent := tag.Get(persistentTagName); len(persistent) > 0 {
+		opt.Persistent, err = strconv.ParseBool(persistent)
+		if err != nil {
+			return opt, errors.New("key '" + key + "': invalid persistent tag '" + persistent + "'")
+		}
+	}
+
+	if secret := tag.Get(secretTagName); len(secret) > 0 {
+		opt.Secret, err = strconv.ParseBool(secret)
+		if err != nil {
+			return opt, errors.New("key '" + key + "': invalid secret tag '" + secret + "'")
+		}
+	}
+
+	elemType := field.Type
+	if elemType.Kind() == reflect.Slice {
+		elemType = elemType.Elem()
+		opt.AcceptValue = true
+		opt.MultiValues = true
+		opt.UniqueValues = true
+	} else {
+		opt.AcceptValue = elemType.Kind() != reflect.Bool
+		opt.OverridePrev = true
+	}
+
+	// time.Duration is an int64 kind, but its values are parsed by duration format
+	if elemType == reflect.TypeOf(time.Duration(0)) {
+		opt.Value = DurationValue
+	}
+
+	switch elemType.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Uint64, reflect.Float64:
+	default:
+		return opt, errors.New("key '" + key + "': unsupported field type " + field.Type.String())
+	}
+
+	if defaultValue, hasDefault := tag.Lookup(defaultTagName); hasDefault {
+		if !opt.AcceptValue {
+			return opt, errors.New("key '" + key + "': default tag '" + defaultValue + "' is not supported on bool field")
+		}
+		if opt.MultiValues {
+			opt.DefaultValues = splitTagList(defaultValue)
+		} else {
+			opt.DefaultValues = []string{defaultValue}
+		}
+	}
+
+	return opt, nil
+}
+
+func NewStructOptions(v interface{}) ([]Option, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, errors.New("options source must be a struct or pointer to struct")
+	}
+
+	options := []Option{}
+	err := walkStructFields(value, func(field reflect.StructField, _ reflect.Value) error {
+		key := field.Tag.Get(bindTagName)
+		if len(key) == 0 || key == "-" {
+			return nil
+		}
+
+		opt, err := newStructFieldOption(key, field)
+		if err != nil {
+			return err
+		}
+		options = append(options, opt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func (s *OptionSet) AddStruct(v interface{}) error {
+	options, err := NewStructOptions(v)
+	if err != nil {
+		return err
+	}
+
+	for _, opt := range options {
+		if err = s.Add(opt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package goNixArgParser
+
+import (
+	"testing"
+	"time"
+)
+
+type structOptions struct {
+	Verbose bool            `arg:"verbose" flags:"-v,--verbose" summary:"verbose output"`
+	Port    int             `arg:"port" flags:"-p,--port" env:"STRUCT_OPTIONS_PORT" default:"80"`
+	Hosts   []string        `arg:"hosts" flags:"--host" delims:"," default:"a,b"`
+	Secret  string          `arg:"secret" hidden:"true"`
+	Timeout time.Duration   `arg:"timeout" default:"3s"`
+	Delays  []time.Duration `arg:"delays"`
+	Ignored string
+}
+
+func TestAddStruct(t *testing.T) {
+	s := NewSimpleOptionSet()
+	err := s.AddStruct(structOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.options) != 6 {
+		t.Fatal(len(s.options))
+	}
+
+	verbose := s.keyOptionMap["verbose"]
+	if verbose.AcceptValue || len(verbose.Flags) != 2 || verbose.Summary != "verbose output" {
+		t.Error(verbose)
+	}
+
+	port := s.keyOptionMap["port"]
+	if !port.AcceptValue || port.MultiValues || !port.OverridePrev || !expectStrings(port.DefaultValues, "80") || !expectStrings(port.EnvVars, "STRUCT_OPTIONS_PORT") {
+		t.Error(port)
+	}
+
+	hosts := s.keyOptionMap["hosts"]
+	if !hosts.MultiValues || !hosts.UniqueValues || !expectStrings(hosts.DefaultValues, "a", "b") || len(hosts.Delimiters) != 1 {
+		t.Error(hosts)
+	}
+
+	secret := s.keyOptionMap["secret"]
+	if !secret.Hidden || secret.Flags[0].Name != "--secret" {
+		t.Error(secret)
+	}
+
+	r := s.Parse([]string{"-vp", "8080", "--host", "x,y,x", "--delays", "1s", "2m"}, nil)
+	var dst structOptions
+	if err = r.Bind(&dst); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.Verbose || dst.Port != 8080 || !expectStrings(dst.Hosts, "x", "y") {
+		t.Error(dst)
+	}
+	if dst.Timeout != 3*time.Second || len(dst.Delays) != 2 || dst.Delays[1] != 2*time.Minute {
+		t.Error(dst.Timeout, dst.Delays)
+	}
+
+	r = s.Parse([]string{"--timeout", "100"}, nil)
+	if err = r.Bind(&dst); err == nil {
+		t.Error("should be invalid duration")
+	}
+
+	if err = s.AddStruct(struct {
+		Bad map[string]string `arg:"bad"`
+	}{}); err == nil {
+		t.Error("should not support map field")
+	}
+
+	if err = s.AddStruct(struct {
+		Flag bool `arg:"flag" default:"true"`
+	}{}); err == nil {
+		t.Error("should not support default on bool field")
+	}
+}
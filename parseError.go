@@ -0,0 +1,119 @@
+package goNixArgParser
+
+import (
+	"io"
+	"strings"
+)
+
+type ParseErrorKind int
+
+const (
+	UndefFlagError ParseErrorKind = iota
+	AmbiguousFlagError
+	UndefCommandError
+)
+
+type ParseError struct {
+	Kind       ParseErrorKind
+	Arg        string
+	Index      int
+	GroupIndex int
+	Source     ValueSource
+
+	Suggestions []string
+}
+
+func (s *OptionSet) newParseError(kind ParseErrorKind, token *argToken) *ParseError {
+	return &ParseError{
+		Kind:        kind,
+		Arg:         token.text,
+		Index:       token.index,
+		Suggestions: s.getFlagSuggestions(kind, token.text),
+	}
+}
+
+func (e *ParseError) Error() string {
+	var msg string
+	switch e.Kind {
+	case AmbiguousFlagError:
+		msg = "ambiguous flag '" + e.Arg + "'"
+	case UndefCommandError:
+		msg = "unknown command '" + e.Arg + "'"
+	default:
+		msg = "undefined flag '" + e.Arg + "'"
+	}
+
+	if e.Source == ConfigSource {
+		msg += " in config"
+	}
+
+	switch len(e.Suggestions) {
+	case 0:
+	case 1:
+		msg += ", did you mean '" + e.Suggestions[0] + "'?"
+	default:
+		msg += ", did you mean one of: " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+
+	return msg
+}
+
+func (e *ParseError) OutputCaret(w io.Writer, args []string) {
+	if e.Index < 0 || e.Index >= len(args) {
+		io.WriteString(w, e.Error())
+		w.Write([]byte{'\n'})
+		return
+	}
+
+	column := 0
+	for i := 0; i < e.Index; i++ {
+		column += len(args[i]) + 1
+	}
+	caretLen := len(args[e.Index])
+	if caretLen == 0 {
+		caretLen = 1
+	}
+
+	io.WriteString(w, strings.Join(args, " "))
+	w.Write([]byte{'\n'})
+	io.WriteString(w, strings.Repeat(" ", column))
+	io.WriteString(w, strings.Repeat("^", caretLen))
+	io.WriteString(w, " ")
+	io.WriteString(w, e.Error())
+	w.Write([]byte{'\n'})
+}
+
+// =============================
+// get errors
+// =============================
+
+func (r *ParseResult) GetParseErrors() []*ParseError {
+	parseErrors := make([]*ParseError, len(r.parseErrors))
+	copy(parseErrors, r.parseErrors)
+	return parseErrors
+}
+
+func (r *ParseResult) GetSuggestions(arg string) []string {
+	for _, parseError := range r.parseErrors {
+		if parseError.Arg == arg {
+			return copys(parseError.Suggestions)
+		}
+	}
+	if r.undefCommand != nil && r.undefCommand.Arg == arg {
+		return copys(r.undefCommand.Suggestions)
+	}
+	return nil
+}
+
+func (r *ParseResult) GetErrors() []error {
+	errs := make([]error, 0, len(r.parseErrors))
+	for _, parseError := range r.parseErrors {
+		errs = append(errs, parseError)
+	}
+
+	if validateErrs, ok := r.Validate().(ErrorList); ok {
+		errs = append(errs, validateErrs...)
+	}
+
+	return errs
+}
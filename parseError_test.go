@@ -0,0 +1,118 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	cmd := getGitCommand()
+	cmdSetUrl := cmd.GetSubCommand("remote").GetSubCommand("set-url")
+	cmdSetUrl.options.Add(Option{Key: "verbose", Flags: []*Flag{NewFlag("--verbose", 3, false, true, false)}})
+	cmdSetUrl.options.Add(Option{Key: "version", Flags: []*Flag{NewFlag("--version", 3, false, true, false)}})
+
+	args := []string{"git", "remote", "set-url", "--push", "--unknown", "origin", ",,", "--ver"}
+	configArgs := []string{"--bad=1"}
+	results := cmd.ParseGroups(args, configArgs)
+	if len(results) != 2 {
+		t.Fatal(len(results))
+	}
+
+	errs := results[0].GetParseErrors()
+	if len(errs) != 2 {
+		t.Fatal(errs)
+	}
+	if errs[0].Kind != UndefFlagError || errs[0].Arg != "--unknown" || errs[0].Index != 4 || errs[0].Source != FlagSource {
+		t.Error(errs[0])
+	}
+	if errs[1].Arg != "--bad" || errs[1].Index != 0 || errs[1].Source != ConfigSource {
+		t.Error(errs[1])
+	}
+	if errs[1].Error() != "undefined flag '--bad' in config" {
+		t.Error(errs[1].Error())
+	}
+
+	errs = results[1].GetParseErrors()
+	if len(errs) != 1 || errs[0].Kind != AmbiguousFlagError || errs[0].Index != 7 || errs[0].GroupIndex != 1 {
+		t.Fatal(errs)
+	}
+
+	buffer := &bytes.Buffer{}
+	results[0].GetParseErrors()[0].OutputCaret(buffer, args)
+	expected := "git remote set-url --push --unknown origin ,, --ver\n" +
+		"                          ^^^^^^^^^ undefined flag '--unknown'\n"
+	if buffer.String() != expected {
+		t.Error(buffer.String())
+	}
+
+	if _, err := cmd.ParseStrict(args, nil); err == nil {
+		t.Error("strict parse should fail")
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	if d := editDistance("--verbsoe", "--verbose"); d != 2 {
+		t.Error(d)
+	}
+
+	cmd := getGitCommand()
+	cmd.GetSubCommand("reset").options.AddFlag("verbose", "--verbose", "", "")
+
+	result := cmd.Parse([]string{"git", "reset", "--verbsoe", "--sfot"}, nil)
+	if suggestions := result.GetSuggestions("--verbsoe"); !expectStrings(suggestions, "--verbose") {
+		t.Error(suggestions)
+	}
+	errs := result.GetParseErrors()
+	if len(errs) != 2 || errs[1].Error() != "undefined flag '--sfot', did you mean '--soft'?" {
+		t.Error(errs)
+	}
+
+	result = cmd.Parse([]string{"git", "remtoe", "set-url"}, nil)
+	if errs = result.GetParseErrors(); len(errs) != 0 {
+		t.Error(errs)
+	}
+	if suggestions := result.GetSuggestions("remtoe"); !expectStrings(suggestions, "remote") {
+		t.Error(suggestions)
+	}
+
+	cmd.SetSubCommandRequired(true)
+	result = cmd.Parse([]string{"git", "remtoe", "set-url"}, nil)
+	errs = result.GetParseErrors()
+	if len(errs) != 1 || errs[0].Kind != UndefCommandError || errs[0].Index != 1 || !expectStrings(errs[0].Suggestions, "remote") {
+		t.Fatal(errs)
+	}
+	if errs[0].Error() != "unknown command 'remtoe', did you mean 'remote'?" {
+		t.Error(errs[0].Error())
+	}
+
+	result = cmd.Parse([]string{"git", "rt", "sett-url"}, nil)
+	if suggestions := result.GetSuggestions("sett-url"); !expectStrings(suggestions, "set-url") {
+		t.Error(suggestions)
+	}
+
+	result = cmd.Parse([]string{"git", "something"}, nil)
+	if errs = result.GetParseErrors(); len(errs) != 1 || errs[0].Error() != "unknown command 'something'" {
+		t.Error(errs)
+	}
+
+	cmd.SetSubCommandRequired(false)
+	result = cmd.Parse([]string{"git", "something"}, nil)
+	if len(result.GetParseErrors()) != 0 {
+		t.Error(result.GetParseErrors())
+	}
+}
+
+func TestUndefCommandNotRequired(t *testing.T) {
+	cmd := NewSimpleCommand("cat", "")
+	cmd.NewSimpleSubCommand("ls", "", "l")
+
+	for _, arg := range []string{"lsx", "x"} {
+		result, err := cmd.ParseStrict([]string{"cat", arg}, nil)
+		if err != nil {
+			t.Error(arg, err)
+		}
+		if rests := result.GetRests(); !expectStrings(rests, arg) {
+			t.Error(rests)
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package goNixArgParser
 
+import "sort"
+
 // =============================
 // set configOptions
 // =============================
 
 func (r *ParseResult) SetConfigOption(key, value string) {
 	r.configOptions[key] = []string{value}
+	delete(r.configFlags, key)
+	delete(r.configFiles, key)
+	delete(r.configShadowed, key)
+
+	if opt := r.keyOptionMap[key]; opt != nil {
+		r.parseValue(opt)
+	}
 }
 
 func (r *ParseResult) SetConfigOptions(key string, values []string) {
@@ -18,6 +27,13 @@ func (r *ParseResult) SetConfigOptions(key string, values []string) {
 	}
 
 	r.configOptions[key] = configValues
+	delete(r.configFlags, key)
+	delete(r.configFiles, key)
+	delete(r.configShadowed, key)
+
+	if opt := r.keyOptionMap[key]; opt != nil {
+		r.parseValue(opt)
+	}
 }
 
 //=============================
@@ -303,3 +319,25 @@ func (r *ParseResult) GetUndefs() []string {
 
 	return flags
 }
+
+// =============================
+// value errors
+// =============================
+
+func (r *ParseResult) HasValueError() bool {
+	return len(r.valueErrors) > 0
+}
+
+func (r *ParseResult) GetValueErrors() []error {
+	keys := make([]string, 0, len(r.valueErrors))
+	for key := range r.valueErrors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		errs[i] = r.valueErrors[key]
+	}
+	return errs
+}
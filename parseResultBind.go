@@ -0,0 +1,143 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"reflect"
+)
+
+const bindTagName = "arg"
+
+func (r *ParseResult) bindField(key string, field reflect.Value) error {
+	if err := r.valueErrors[key]; err != nil {
+		return err
+	}
+	if values, hasValues := r.values[key]; hasValues {
+		if bindParsedValues(values, field) {
+			return nil
+		}
+	}
+
+	strs, source := r.getSourceValues(key)
+	if source == NoneSource {
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice {
+		return withErrorSource(bindSlice(key, strs, field), source)
+	}
+
+	if field.Kind() == reflect.Bool && len(strs) == 0 {
+		// flag without value
+		field.SetBool(true)
+		return nil
+	}
+
+	var str string
+	if len(strs) > 0 {
+		str = strs[0]
+	}
+
+	return withErrorSource(bindSingle(key, str, field), source)
+}
+
+func withErrorSource(err error, source ValueSource) error {
+	if valueErr, ok := err.(*ValueError); ok {
+		valueErr.Source = source
+	}
+	return err
+}
+
+func bindSingle(key, str string, field reflect.Value) error {
+	var err error
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(str)
+	case reflect.Bool:
+		var v bool
+		if v, err = toBool(str); err == nil {
+			field.SetBool(v)
+		}
+	case reflect.Int:
+		var v int
+		if v, err = toInt(str); err == nil {
+			field.SetInt(int64(v))
+		}
+	case reflect.Int64:
+		var v int64
+		if v, err = toInt64(str); err == nil {
+			field.SetInt(v)
+		}
+	case reflect.Uint64:
+		var v uint64
+		if v, err = toUint64(str); err == nil {
+			field.SetUint(v)
+		}
+	case reflect.Float64:
+		var v float64
+		if v, err = toFloat64(str); err == nil {
+			field.SetFloat(v)
+		}
+	default:
+		return errors.New("key '" + key + "': unsupported field type " + field.Type().String())
+	}
+
+	if err != nil {
+		return &ValueError{Key: key, Value: str, Err: err}
+	}
+	return nil
+}
+
+func bindSlice(key string, strs []string, field reflect.Value) error {
+	slice := reflect.MakeSlice(field.Type(), len(strs), len(strs))
+	for i, str := range strs {
+		if err := bindSingle(key, str, slice.Index(i)); err != nil {
+			return err
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+func isAssignable(value interface{}, t reflect.Type) bool {
+	valueType := reflect.TypeOf(value)
+	return valueType != nil && valueType.AssignableTo(t)
+}
+
+func bindParsedValues(values []interface{}, field reflect.Value) bool {
+	fieldType := field.Type()
+
+	if len(values) > 0 && isAssignable(values[0], fieldType) {
+		field.Set(reflect.ValueOf(values[0]))
+		return true
+	}
+
+	if fieldType.Kind() != reflect.Slice {
+		return false
+	}
+
+	slice := reflect.MakeSlice(fieldType, len(values), len(values))
+	for i, value := range values {
+		if !isAssignable(value, fieldType.Elem()) {
+			return false
+		}
+		slice.Index(i).Set(reflect.ValueOf(value))
+	}
+	field.Set(slice)
+	return true
+}
+
+func (r *ParseResult) Bind(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return errors.New("bind target must be a non-nil pointer to struct")
+	}
+
+	return walkStructFields(ptr.Elem(), func(field reflect.StructField, value reflect.Value) error {
+		key := field.Tag.Get(bindTagName)
+		if len(key) == 0 || key == "-" {
+			return nil
+		}
+		return r.bindField(key, value)
+	})
+}
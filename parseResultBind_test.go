@@ -0,0 +1,78 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	s := NewSimpleOptionSet()
+	s.AddFlag("verbose", "-v", "", "")
+	s.AddFlagValue("name", "--name", "", "", "")
+	s.AddFlagValue("port", "--port", "", "80", "")
+	s.AddFlagValue("size", "--size", "", "", "")
+	s.AddFlagValue("ratio", "--ratio", "", "", "")
+	s.AddFlagValues("ids", "--ids", "", nil, "")
+	s.AddFlagValues("weights", "--weights", "", nil, "")
+
+	type embedded struct {
+		Ratio float64 `arg:"ratio"`
+	}
+	var dst struct {
+		embedded
+		Verbose bool      `arg:"verbose"`
+		Name    string    `arg:"name"`
+		Port    int       `arg:"port"`
+		Size    uint64    `arg:"size"`
+		Ids     []int64   `arg:"ids"`
+		Weights []float64 `arg:"weights"`
+		Missing string    `arg:"missing"`
+		Ignored string
+	}
+	dst.Missing = "keep"
+
+	r := s.Parse([]string{"-v", "--name", "foo", "--size", "1024", "--ratio", "0.5", "--ids", "1", "2", "3"}, nil)
+	err := r.Bind(&dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dst.Verbose {
+		t.Error("verbose")
+	}
+	if dst.Name != "foo" {
+		t.Error(dst.Name)
+	}
+	if dst.Port != 80 {
+		t.Error(dst.Port)
+	}
+	if dst.Size != 1024 {
+		t.Error(dst.Size)
+	}
+	if dst.Ratio != 0.5 {
+		t.Error(dst.Ratio)
+	}
+	if len(dst.Ids) != 3 || dst.Ids[0] != 1 || dst.Ids[2] != 3 {
+		t.Error(dst.Ids)
+	}
+	if dst.Weights != nil {
+		t.Error(dst.Weights)
+	}
+	if dst.Missing != "keep" {
+		t.Error(dst.Missing)
+	}
+
+	r = s.Parse([]string{"--ids", "1", "x2"}, nil)
+	err = r.Bind(&dst)
+	var valueErr *ValueError
+	if !errors.As(err, &valueErr) {
+		t.Fatal(err)
+	}
+	if valueErr.Key != "ids" || valueErr.Value != "x2" {
+		t.Error(valueErr)
+	}
+
+	if err = r.Bind(dst); err == nil {
+		t.Error("should not bind to non-pointer")
+	}
+}
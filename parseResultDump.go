@@ -0,0 +1,220 @@
+package goNixArgParser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	ConfigTableFormat = "table"
+	ConfigJSONFormat  = "json"
+	ConfigArgsFormat  = "args"
+
+	ConfigFormatKey = "configFormat"
+
+	RedactedValue = "******"
+)
+
+type configEntry struct {
+	opt *Option
+	*Provenance
+}
+
+func (r *ParseResult) isInternalOption(opt *Option) bool {
+	return (r.command != nil && opt == r.command.configFormatOption) || r.isBuiltinKey(opt.Key)
+}
+
+func (r *ParseResult) getConfigEntries() []*configEntry {
+	entries := make([]*configEntry, 0, len(r.options))
+	for _, opt := range r.options {
+		if r.isInternalOption(opt) {
+			continue
+		}
+
+		p := r.GetProvenance(opt.Key)
+		if opt.Secret && p.Source != NoneSource {
+			p.Values = []string{RedactedValue}
+			p.Shadowed = nil
+		}
+		entries = append(entries, &configEntry{opt, p})
+	}
+	return entries
+}
+
+func (e *configEntry) effectiveValues() []string {
+	if !e.opt.AcceptValue && e.Source != NoneSource && len(e.Values) == 0 {
+		return []string{"true"}
+	}
+	return e.Values
+}
+
+// =============================
+// table
+// =============================
+
+func (r *ParseResult) OutputConfigTable(w io.Writer) {
+	rows := [][]string{{"KEY", "VALUE", "SOURCE"}}
+	for _, entry := range r.getConfigEntries() {
+		rows = append(rows, []string{entry.Key, strings.Join(entry.effectiveValues(), ", "), entry.describe()})
+	}
+
+	widths := make([]int, 2)
+	for _, row := range rows {
+		for i := range widths {
+			if len(row[i]) > widths[i] {
+				widths[i] = len(row[i])
+			}
+		}
+	}
+
+	for _, row := range rows {
+		line := ""
+		for i := range widths {
+			line += row[i] + strings.Repeat(" ", widths[i]-len(row[i])+2)
+		}
+		io.WriteString(w, line+row[2]+"\n")
+	}
+}
+
+// =============================
+// json
+// =============================
+
+type configJSONEntry struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+	Source string   `json:"source"`
+	Flag   string   `json:"flag,omitempty"`
+	EnvVar string   `json:"envVar,omitempty"`
+	File   string   `json:"file,omitempty"`
+}
+
+func (r *ParseResult) OutputConfigJSON(w io.Writer) error {
+	entries := r.getConfigEntries()
+	jsonEntries := make([]*configJSONEntry, len(entries))
+	for i, entry := range entries {
+		values := entry.effectiveValues()
+		if values == nil {
+			values = []string{}
+		}
+		jsonEntries[i] = &configJSONEntry{
+			Key:    entry.Key,
+			Values: values,
+			Source: entry.Source.String(),
+			Flag:   entry.Flag,
+			EnvVar: entry.EnvVar,
+			File:   entry.File,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonEntries)
+}
+
+// =============================
+// args
+// =============================
+
+func argQuote(input string) string {
+	if len(input) > 0 && !strings.ContainsAny(input, " \t\r\n'\"") {
+		return input
+	}
+
+	var sb strings.Builder
+	for i, part := range strings.Split(input, "'") {
+		if i > 0 {
+			sb.WriteString(`"'"`)
+		}
+		if len(part) > 0 || i == 0 {
+			sb.WriteString("'" + part + "'")
+		}
+	}
+	return sb.String()
+}
+
+func (e *configEntry) args() []string {
+	if len(e.opt.Flags) == 0 || e.Source == NoneSource || e.Source == DefaultSource {
+		return nil
+	}
+	if e.opt.Secret {
+		// redacted value cannot be parsed back
+		return nil
+	}
+
+	flagName := e.opt.Flags[0].Name
+	if !e.opt.AcceptValue {
+		if len(e.Values) > 0 {
+			if value, err := toBool(e.Values[0]); err != nil || !value {
+				return nil
+			}
+		}
+		return []string{flagName}
+	}
+
+	return append([]string{flagName}, e.Values...)
+}
+
+func (r *ParseResult) GetConfigArgs() []string {
+	args := []string{}
+	for _, entry := range r.getConfigEntries() {
+		args = append(args, entry.args()...)
+	}
+	return args
+}
+
+func (r *ParseResult) OutputConfigArgs(w io.Writer) {
+	for _, entry := range r.getConfigEntries() {
+		args := entry.args()
+		if len(args) == 0 {
+			continue
+		}
+		for i := range args {
+			args[i] = argQuote(args[i])
+		}
+		io.WriteString(w, strings.Join(args, " ")+"\n")
+	}
+}
+
+// =============================
+// output by format
+// =============================
+
+func (r *ParseResult) OutputConfig(w io.Writer, format string) error {
+	switch format {
+	case ConfigTableFormat, "":
+		r.OutputConfigTable(w)
+		return nil
+	case ConfigJSONFormat:
+		return r.OutputConfigJSON(w)
+	case ConfigArgsFormat:
+		r.OutputConfigArgs(w)
+		return nil
+	default:
+		return errors.New("unknown config format '" + format + "'")
+	}
+}
+
+func (c *Command) NewConfigShowSubCommand(name, summary string, aliasNames ...string) *Command {
+	cmd := c.NewSimpleSubCommand(name, summary, aliasNames...)
+	err := cmd.options.Add(Option{
+		Key:           ConfigFormatKey,
+		Flags:         NewSimpleFlags([]string{"--format"}),
+		AcceptValue:   true,
+		OverridePrev:  true,
+		DefaultValues: []string{ConfigTableFormat},
+		Summary:       "output format: " + ConfigTableFormat + ", " + ConfigJSONFormat + " or " + ConfigArgsFormat,
+		Value:         EnumValue(ConfigTableFormat, ConfigJSONFormat, ConfigArgsFormat),
+	})
+	if err == nil {
+		cmd.configFormatOption = cmd.options.keyOptionMap[ConfigFormatKey]
+	}
+	cmd.SetHandler(func(ctx context.Context, result *ParseResult) error {
+		format, _ := result.GetString(ConfigFormatKey)
+		return result.OutputConfig(cmd.getOutput(), format)
+	})
+	return cmd
+}
@@ -0,0 +1,101 @@
+package goNixArgParser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func getDumpCommand() *Command {
+	cmd := NewSimpleCommand("app", "")
+	cmd.EnableHelp()
+	cmd.options.Add(Option{Key: "port", Flags: NewSimpleFlags([]string{"--port", "-p"}), AcceptValue: true, DefaultValues: []string{"3000"}, Persistent: true})
+	cmd.options.Add(Option{Key: "tags", Flags: NewSimpleFlags([]string{"--tag"}), AcceptValue: true, MultiValues: true, Persistent: true})
+	cmd.options.Add(Option{Key: "token", Flags: NewSimpleFlags([]string{"--token"}), AcceptValue: true, Secret: true, Persistent: true})
+	cmd.options.Add(Option{Key: "verbose", Flags: NewSimpleFlags([]string{"-v"}), Persistent: true})
+	cmd.options.Add(Option{Key: "name", Flags: NewSimpleFlags([]string{"--name"}), AcceptValue: true, Persistent: true})
+
+	cmdConfig := cmd.NewSimpleSubCommand("config", "")
+	cmdConfig.NewConfigShowSubCommand("show", "show effective config")
+	return cmd
+}
+
+func TestOutputConfig(t *testing.T) {
+	cmd := getDumpCommand()
+	result := cmd.Parse([]string{"app", "-p", "8080", "--tag", "a b", "it's", "--token", "secret", "-v"}, []string{"--port", "80"})
+
+	buffer := &bytes.Buffer{}
+	result.OutputConfigTable(buffer)
+	expect := "" +
+		"KEY      VALUE      SOURCE\n" +
+		"port     8080       flag -p\n" +
+		"tags     a b, it's  flag --tag\n" +
+		"token    ******     flag --token\n" +
+		"verbose  true       flag -v\n" +
+		"name                none\n"
+	if buffer.String() != expect {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	if err := result.OutputConfigJSON(buffer); err != nil {
+		t.Error(err)
+	}
+	if !strings.HasPrefix(buffer.String(), "[\n  {\n    \"key\": \"port\",\n    \"values\": [\n      \"8080\"\n    ],\n    \"source\": \"flag\",\n    \"flag\": \"-p\"\n  },") ||
+		!strings.Contains(buffer.String(), "\"key\": \"name\",\n    \"values\": [],\n    \"source\": \"none\"\n") ||
+		strings.Contains(buffer.String(), "secret") {
+		t.Error(buffer.String())
+	}
+
+	args := result.GetConfigArgs()
+	if strings.Join(args, "|") != "--port|8080|--tag|a b|it's|-v" {
+		t.Error(args)
+	}
+
+	buffer.Reset()
+	result.OutputConfigArgs(buffer)
+	if buffer.String() != "--port 8080\n--tag 'a b' 'it'\"'\"'s'\n-v\n" {
+		t.Error(buffer.String())
+	}
+	reparsed := cmd.Parse(append([]string{"app"}, SplitToArgs(buffer.String())...), nil)
+	if tags, _ := reparsed.GetStrings("tags"); len(tags) != 2 || tags[0] != "a b" || tags[1] != "it's" {
+		t.Error(tags)
+	}
+
+	if err := result.OutputConfig(buffer, "xml"); err == nil {
+		t.Error("should fail for unknown format")
+	}
+}
+
+func TestConfigShowSubCommand(t *testing.T) {
+	cmd := getDumpCommand()
+	buffer := &bytes.Buffer{}
+	cmd.SetOutput(buffer)
+
+	if err := cmd.Execute([]string{"app", "config", "show", "--format", "args", "--name", "x y"}); err != nil {
+		t.Error(err)
+	}
+	if buffer.String() != "--name 'x y'\n" {
+		t.Error(buffer.String())
+	}
+
+	buffer.Reset()
+	if err := cmd.Execute([]string{"app", "config", "show"}); err != nil {
+		t.Error(err)
+	}
+	if !strings.HasPrefix(buffer.String(), "KEY      VALUE  SOURCE\nport     3000   default\n") || strings.Contains(buffer.String(), ConfigFormatKey) {
+		t.Error(buffer.String())
+	}
+
+	if err := cmd.Execute([]string{"app", "config", "show", "--format", "xml"}); err == nil {
+		t.Error("should fail for invalid format")
+	}
+
+	cmd.NewSimpleSubCommand("export", "").options.AddFlagValue(ConfigFormatKey, "--config-format", "", "ini", "")
+	result := cmd.Parse([]string{"app", "export"}, nil)
+	buffer.Reset()
+	result.OutputConfigTable(buffer)
+	if !strings.Contains(buffer.String(), ConfigFormatKey+"  ini") {
+		t.Error(buffer.String())
+	}
+}
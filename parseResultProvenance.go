@@ -0,0 +1,124 @@
+package goNixArgParser
+
+import (
+	"strconv"
+	"strings"
+)
+
+type ValueOrigin struct {
+	Source ValueSource
+	Values []string
+	Flag   string // flag name, for FlagSource and ConfigSource
+	EnvVar string // env var name, for EnvSource
+	File   string // config file name, for ConfigSource if known
+}
+
+func (o *ValueOrigin) describe() string {
+	var sb strings.Builder
+	sb.WriteString(o.Source.String())
+
+	switch o.Source {
+	case FlagSource:
+		sb.WriteString(" " + o.Flag)
+	case EnvSource:
+		sb.WriteString(" " + o.EnvVar)
+	case ConfigSource:
+		if len(o.Flag) > 0 {
+			sb.WriteString(" " + o.Flag)
+		}
+		if len(o.File) > 0 {
+			sb.WriteString(" in " + o.File)
+		}
+	}
+
+	return sb.String()
+}
+
+func (o *ValueOrigin) String() string {
+	var sb strings.Builder
+	sb.WriteString(o.describe())
+
+	for i, value := range o.Values {
+		if i == 0 {
+			sb.WriteString(" ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.Quote(value))
+	}
+
+	return sb.String()
+}
+
+type Provenance struct {
+	Key string
+	ValueOrigin
+	Shadowed []ValueOrigin
+}
+
+func (p *Provenance) String() string {
+	msg := p.Key + ": "
+	if p.Source == NoneSource {
+		msg += "not set"
+	} else {
+		msg += p.ValueOrigin.String()
+	}
+	for i := range p.Shadowed {
+		if i == 0 {
+			msg += "; shadows "
+		} else {
+			msg += ", "
+		}
+		msg += p.Shadowed[i].String()
+	}
+	return msg
+}
+
+// effective origins are the ones still in effect, while config values cleared by later config files are not
+func (r *ParseResult) getValueOrigins(key string) (effectives []bool, origins []ValueOrigin) {
+	add := func(effective bool, origin ...ValueOrigin) {
+		for range origin {
+			effectives = append(effectives, effective)
+		}
+		origins = append(origins, origin...)
+	}
+
+	if values, found := r.specifiedOptions[key]; found {
+		add(true, ValueOrigin{Source: FlagSource, Values: copys(values), Flag: r.specifiedFlags[key]})
+	}
+	if values, found := r.envs[key]; found {
+		add(true, ValueOrigin{Source: EnvSource, Values: copys(values), EnvVar: r.envVars[key]})
+	}
+	if values, found := r.configOptions[key]; found {
+		add(true, ValueOrigin{Source: ConfigSource, Values: copys(values), Flag: r.configFlags[key], File: r.configFiles[key]})
+	}
+	add(false, r.configShadowed[key]...)
+	if values, found := r.defaults[key]; found {
+		add(true, ValueOrigin{Source: DefaultSource, Values: copys(values)})
+	}
+
+	return
+}
+
+func (r *ParseResult) GetProvenance(key string) *Provenance {
+	p := &Provenance{Key: key}
+
+	effectives, origins := r.getValueOrigins(key)
+	for i := range origins {
+		if effectives[i] && p.Source == NoneSource {
+			p.ValueOrigin = origins[i]
+		} else {
+			p.Shadowed = append(p.Shadowed, origins[i])
+		}
+	}
+
+	return p
+}
+
+func (r *ParseResult) GetProvenances() []*Provenance {
+	provenances := make([]*Provenance, len(r.options))
+	for i, opt := range r.options {
+		provenances[i] = r.GetProvenance(opt.Key)
+	}
+	return provenances
+}
@@ -0,0 +1,54 @@
+package goNixArgParser
+
+import (
+	"testing"
+)
+
+func TestGetProvenance(t *testing.T) {
+	t.Setenv("TEST_PROVENANCE_PORT", "9090")
+
+	s := NewSimpleOptionSet()
+	s.AddFlagValue("port", "--port", "TEST_PROVENANCE_PORT", "3000", "")
+	s.AddFlagValue("host", "--host", "", "localhost", "")
+	s.AddFlag("verbose", "-v", "", "")
+	s.AddFlagValue("user", "--user", "", "", "")
+
+	result := s.Parse([]string{"--port", "8080", "-v"}, []string{"--port", "80", "--host", "example.com"})
+
+	p := result.GetProvenance("port")
+	if p.Source != FlagSource || p.Flag != "--port" || len(p.Values) != 1 || p.Values[0] != "8080" {
+		t.Error(p)
+	}
+	if len(p.Shadowed) != 3 ||
+		p.Shadowed[0].Source != EnvSource || p.Shadowed[0].EnvVar != "TEST_PROVENANCE_PORT" || p.Shadowed[0].Values[0] != "9090" ||
+		p.Shadowed[1].Source != ConfigSource || p.Shadowed[1].Flag != "--port" || p.Shadowed[1].Values[0] != "80" ||
+		p.Shadowed[2].Source != DefaultSource || p.Shadowed[2].Values[0] != "3000" {
+		t.Error(p.Shadowed)
+	}
+	if p.String() != `port: flag --port "8080"; shadows env TEST_PROVENANCE_PORT "9090", config --port "80", default "3000"` {
+		t.Error(p.String())
+	}
+
+	p = result.GetProvenance("host")
+	if p.String() != `host: config --host "example.com"; shadows default "localhost"` {
+		t.Error(p.String())
+	}
+
+	result.SetConfigOption("host", "example.org")
+	p = result.GetProvenance("host")
+	if p.Source != ConfigSource || p.Flag != "" || p.Values[0] != "example.org" {
+		t.Error(p)
+	}
+
+	if p = result.GetProvenance("verbose"); p.String() != "verbose: flag -v" {
+		t.Error(p.String())
+	}
+	if p = result.GetProvenance("user"); p.Source != NoneSource || p.String() != "user: not set" {
+		t.Error(p.String())
+	}
+
+	provenances := result.GetProvenances()
+	if len(provenances) != 4 || provenances[0].Key != "port" || provenances[3].Key != "user" {
+		t.Error(provenances)
+	}
+}
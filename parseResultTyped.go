@@ -0,0 +1,173 @@
+package goNixArgParser
+
+import "errors"
+
+type ValueSource int
+
+const (
+	NoneSource ValueSource = iota
+	FlagSource
+	EnvSource
+	ConfigSource
+	DefaultSource
+)
+
+func (s ValueSource) String() string {
+	switch s {
+	case FlagSource:
+		return "flag"
+	case EnvSource:
+		return "env"
+	case ConfigSource:
+		return "config"
+	case DefaultSource:
+		return "default"
+	default:
+		return "none"
+	}
+}
+
+var (
+	ErrValueAbsent = errors.New("value absent")
+	ErrValueEmpty  = errors.New("value empty")
+)
+
+type ValueError struct {
+	Key    string
+	Source ValueSource
+	Value  string
+	Err    error
+}
+
+func (e *ValueError) Error() string {
+	if e.Err == ErrValueAbsent || e.Err == ErrValueEmpty {
+		return "key '" + e.Key + "': " + e.Err.Error()
+	}
+
+	msg := "invalid value '" + e.Value + "' for key '" + e.Key + "'"
+	if e.Source != NoneSource {
+		msg += " from " + e.Source.String()
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+func (r *ParseResult) getSourceValues(key string) (values []string, source ValueSource) {
+	if values, found := r.specifiedOptions[key]; found {
+		return values, FlagSource
+	}
+	if values, found := r.envs[key]; found {
+		return values, EnvSource
+	}
+	if values, found := r.configOptions[key]; found {
+		return values, ConfigSource
+	}
+	if values, found := r.defaults[key]; found {
+		return values, DefaultSource
+	}
+	return nil, NoneSource
+}
+
+func (r *ParseResult) GetSource(key string) ValueSource {
+	_, source := r.getSourceValues(key)
+	return source
+}
+
+func getConvertedValue[T any](r *ParseResult, key string, allowEmpty bool, convert func(string) (T, error)) (value T, err error) {
+	values, source := r.getSourceValues(key)
+	if source == NoneSource {
+		return value, &ValueError{Key: key, Err: ErrValueAbsent}
+	}
+
+	var str string
+	if len(values) > 0 {
+		str = values[0]
+	}
+	if len(str) == 0 && !allowEmpty {
+		return value, &ValueError{Key: key, Source: source, Err: ErrValueEmpty}
+	}
+
+	value, err = convert(str)
+	if err != nil {
+		return value, &ValueError{Key: key, Source: source, Value: str, Err: err}
+	}
+	return value, nil
+}
+
+func getConvertedValues[T any](r *ParseResult, key string, allowEmpty bool, convert func(string) (T, error)) (values []T, err error) {
+	strs, source := r.getSourceValues(key)
+	if source == NoneSource {
+		return nil, &ValueError{Key: key, Err: ErrValueAbsent}
+	}
+	if len(strs) == 0 && !allowEmpty {
+		return nil, &ValueError{Key: key, Source: source, Err: ErrValueEmpty}
+	}
+
+	values = make([]T, len(strs))
+	for i, str := range strs {
+		values[i], err = convert(str)
+		if err != nil {
+			return nil, &ValueError{Key: key, Source: source, Value: str, Err: err}
+		}
+	}
+	return values, nil
+}
+
+//=============================
+// get single value with error
+//=============================
+
+func (r *ParseResult) GetStringE(key string) (string, error) {
+	return getConvertedValue(r, key, false, toString)
+}
+
+func (r *ParseResult) GetBoolE(key string) (bool, error) {
+	return getConvertedValue(r, key, true, toBool)
+}
+
+func (r *ParseResult) GetIntE(key string) (int, error) {
+	return getConvertedValue(r, key, false, toInt)
+}
+
+func (r *ParseResult) GetInt64E(key string) (int64, error) {
+	return getConvertedValue(r, key, false, toInt64)
+}
+
+func (r *ParseResult) GetUint64E(key string) (uint64, error) {
+	return getConvertedValue(r, key, false, toUint64)
+}
+
+func (r *ParseResult) GetFloat64E(key string) (float64, error) {
+	return getConvertedValue(r, key, false, toFloat64)
+}
+
+//=============================
+// get multi values with error
+//=============================
+
+func (r *ParseResult) GetStringsE(key string) ([]string, error) {
+	return getConvertedValues(r, key, false, toString)
+}
+
+func (r *ParseResult) GetBoolsE(key string) ([]bool, error) {
+	return getConvertedValues(r, key, true, toBool)
+}
+
+func (r *ParseResult) GetIntsE(key string) ([]int, error) {
+	return getConvertedValues(r, key, false, toInt)
+}
+
+func (r *ParseResult) GetInt64sE(key string) ([]int64, error) {
+	return getConvertedValues(r, key, false, toInt64)
+}
+
+func (r *ParseResult) GetUint64sE(key string) ([]uint64, error) {
+	return getConvertedValues(r, key, false, toUint64)
+}
+
+func (r *ParseResult) GetFloat64sE(key string) ([]float64, error) {
+	return getConvertedValues(r, key, false, toFloat64)
+}
@@ -0,0 +1,58 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGetE(t *testing.T) {
+	os.Setenv("GET_E_TIMEOUT", "abc")
+	defer os.Unsetenv("GET_E_TIMEOUT")
+
+	s := NewSimpleOptionSet()
+	s.AddFlagValue("port", "--port", "", "", "")
+	s.AddFlagValue("timeout", "--timeout", "GET_E_TIMEOUT", "", "")
+	s.AddFlagValue("retry", "--retry", "", "3", "")
+	s.AddFlagValues("ids", "--ids", "", nil, "")
+	s.AddFlag("verbose", "-v", "", "")
+
+	r := s.Parse([]string{"--port", "-v", "--ids", "1", "x"}, nil)
+
+	var valueErr *ValueError
+
+	_, err := r.GetIntE("missing")
+	if !errors.Is(err, ErrValueAbsent) {
+		t.Error(err)
+	}
+
+	_, err = r.GetIntE("port")
+	if !errors.Is(err, ErrValueEmpty) || !errors.As(err, &valueErr) || valueErr.Source != FlagSource {
+		t.Error(err)
+	}
+
+	_, err = r.GetIntE("timeout")
+	if !errors.As(err, &valueErr) || valueErr.Source != EnvSource || valueErr.Value != "abc" {
+		t.Error(err)
+	}
+
+	retry, err := r.GetIntE("retry")
+	if err != nil || retry != 3 || r.GetSource("retry") != DefaultSource {
+		t.Error(retry, err)
+	}
+
+	verbose, err := r.GetBoolE("verbose")
+	if err != nil || verbose {
+		t.Error(verbose, err)
+	}
+
+	_, err = r.GetIntsE("ids")
+	if !errors.As(err, &valueErr) || valueErr.Value != "x" {
+		t.Error(err)
+	}
+
+	ids, err := r.GetStringsE("ids")
+	if err != nil || !expectStrings(ids, "1", "x") {
+		t.Error(ids, err)
+	}
+}
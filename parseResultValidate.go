@@ -0,0 +1,100 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"strings"
+)
+
+type ErrorList []error
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (l ErrorList) Unwrap() []error {
+	return l
+}
+
+// Is and As work before Go 1.20, which does not support Unwrap() []error
+func (l ErrorList) Is(target error) bool {
+	for _, err := range l {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ErrorList) As(target interface{}) bool {
+	for _, err := range l {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ErrorList) orNil() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+type RequiredError struct {
+	Keys  []string
+	Flags []string
+}
+
+func (e *RequiredError) Error() string {
+	return "missing required options: " + strings.Join(e.Flags, ", ")
+}
+
+func (opt *Option) displayName() string {
+	if len(opt.Flags) > 0 {
+		return opt.Flags[0].Name
+	}
+	return opt.Key
+}
+
+func (r *ParseResult) isOptionSupplied(opt *Option) bool {
+	if opt.AcceptValue {
+		return r.HasValue(opt.Key)
+	}
+	return r.HasKey(opt.Key)
+}
+
+func (r *ParseResult) GetMissingRequireds() []string {
+	keys := []string{}
+	for _, opt := range r.options {
+		if opt.Required && !r.isOptionSupplied(opt) {
+			keys = append(keys, opt.Key)
+		}
+	}
+	return keys
+}
+
+func (r *ParseResult) Validate() error {
+	var errs ErrorList
+
+	if keys := r.GetMissingRequireds(); len(keys) > 0 {
+		flags := make([]string, len(keys))
+		for i, key := range keys {
+			flags[i] = r.keyOptionMap[key].displayName()
+		}
+		errs = append(errs, &RequiredError{Keys: keys, Flags: flags})
+	}
+
+	for _, violation := range r.GetViolations() {
+		errs = append(errs, violation)
+	}
+
+	errs = append(errs, r.GetValueErrors()...)
+	errs = append(errs, r.GetPositionalErrors()...)
+
+	return errs.orNil()
+}
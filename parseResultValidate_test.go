@@ -0,0 +1,67 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestValidateRequired(t *testing.T) {
+	os.Setenv("VALIDATE_HOST", "localhost")
+	defer os.Unsetenv("VALIDATE_HOST")
+
+	s := NewSimpleOptionSet()
+	s.Add(Option{Key: "port", Flags: NewSimpleFlags([]string{"--port"}), AcceptValue: true, Required: true})
+	s.Add(Option{Key: "host", Flags: NewSimpleFlags([]string{"--host"}), AcceptValue: true, Required: true, EnvVars: []string{"VALIDATE_HOST"}})
+	s.Add(Option{Key: "user", Flags: NewSimpleFlags([]string{"-u", "--user"}), AcceptValue: true, Required: true})
+	s.Add(Option{Key: "force", Flags: NewSimpleFlags([]string{"--force"}), Required: true})
+	s.Add(Option{Key: "timeout", Flags: NewSimpleFlags([]string{"--timeout"}), AcceptValue: true, Value: DurationValue})
+
+	r, err := s.ParseStrict([]string{"--port", "--timeout", "1x"}, []string{"--user", "root"})
+	if !expectStrings(r.GetMissingRequireds(), "port", "force") {
+		t.Error(r.GetMissingRequireds())
+	}
+
+	errs, ok := err.(ErrorList)
+	if !ok || len(errs) != 2 {
+		t.Fatal(err)
+	}
+
+	var requiredErr *RequiredError
+	if !errors.As(errs[0], &requiredErr) || !expectStrings(requiredErr.Flags, "--port", "--force") {
+		t.Error(errs[0])
+	}
+
+	var valueErr *ValueError
+	if !errors.As(errs[1], &valueErr) || valueErr.Key != "timeout" {
+		t.Error(errs[1])
+	}
+
+	r, err = s.ParseStrict([]string{"--port", "80", "--force"}, []string{"--user", "root"})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestErrorListIsAs(t *testing.T) {
+	var err error = ErrorList{
+		&ConfigError{Key: "a", Err: ErrInvalidConfigValue},
+		&ConfigError{Key: "b", Err: ErrUnknownConfigKey},
+	}
+
+	if !err.(ErrorList).Is(ErrUnknownConfigKey) || !errors.Is(err, ErrUnknownConfigKey) {
+		t.Error("should be ErrUnknownConfigKey")
+	}
+	if err.(ErrorList).Is(ErrConfigMultipleValues) {
+		t.Error("should not be ErrConfigMultipleValues")
+	}
+
+	var configErr *ConfigError
+	if !err.(ErrorList).As(&configErr) || configErr.Key != "a" {
+		t.Error(configErr)
+	}
+	var requiredErr *RequiredError
+	if err.(ErrorList).As(&requiredErr) {
+		t.Error(requiredErr)
+	}
+}
@@ -1,8 +1,20 @@
 package goNixArgParser
 
-func newToken(text string, argType argKind) *argToken {
+func newToken(text string, argType argKind, index int) *argToken {
 	return &argToken{
-		text: text,
-		kind: argType,
+		text:  text,
+		raw:   text,
+		kind:  argType,
+		index: index,
 	}
 }
+
+func (t *argToken) newSplitToken(text string, argType argKind, typed string) *argToken {
+	token := newToken(text, argType, t.index)
+	if t.raw != t.text {
+		token.raw = t.raw
+	} else {
+		token.raw = typed
+	}
+	return token
+}
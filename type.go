@@ -1,10 +1,34 @@
 package goNixArgParser
 
+import (
+	"context"
+	"io"
+)
+
+type Handler func(ctx context.Context, result *ParseResult) error
+
+type CompleteFunc func(result *ParseResult, prefix string) []string
+
 type Command struct {
+	parent *Command
+
 	names       []string
 	summary     string
 	options     *OptionSet
 	subCommands []*Command
+
+	helpOption    *Option
+	helpAllOption *Option
+	versionOption *Option
+	version       string
+
+	configFormatOption *Option
+
+	handler            Handler
+	output             io.Writer
+	interleaved        bool
+	subCommandRequired bool
+	helpFormatter      HelpFormatter
 }
 
 type OptionSet struct {
@@ -24,22 +48,46 @@ type OptionSet struct {
 	flagOptionMap map[string]*Option
 	nameFlagMap   map[string]*Flag
 	keyEnvMap     map[string][]string
+	keyEnvVarMap  map[string]string
 	keyDefaultMap map[string][]string
+
+	constraints []*constraint
+	positionals []*Positional
+	groups      []*optionGroup
+
+	helpFormatter HelpFormatter
 }
 
 type Option struct {
-	Key           string
-	Summary       string
-	Description   string
-	Flags         []*Flag
-	AcceptValue   bool
-	MultiValues   bool
-	OverridePrev  bool
-	Delimiters    []rune
-	UniqueValues  bool
-	EnvVars       []string
-	DefaultValues []string
-	Hidden        bool
+	Key              string
+	Summary          string
+	Description      string
+	Flags            []*Flag
+	AcceptValue      bool
+	ValuePlaceholder string
+	MultiValues      bool
+	OverridePrev     bool
+	Delimiters       []rune
+	UniqueValues     bool
+	EnvVars          []string
+	DefaultValues    []string
+	Hidden           bool
+	Required         bool
+	Persistent       bool
+	Secret           bool
+	Value            Value
+	Complete         CompleteFunc
+
+	group *optionGroup
+}
+
+type Positional struct {
+	Name     string
+	Summary  string
+	Required bool
+	Variadic bool
+	Value    Value
+	Complete CompleteFunc
 }
 
 type Flag struct {
@@ -67,11 +115,16 @@ const (
 )
 
 type argToken struct {
-	text string
-	kind argKind
+	text  string
+	raw   string // original arg text before splitting and rewriting
+	kind  argKind
+	index int
 }
 
 type ParseResult struct {
+	command *Command
+
+	options      []*Option
 	keyOptionMap map[string]*Option
 
 	commands         []string
@@ -80,6 +133,17 @@ type ParseResult struct {
 	configOptions    map[string][]string
 	defaults         map[string][]string
 
+	specifiedFlags map[string]string
+	envVars        map[string]string
+	configFlags    map[string]string
+	configFiles    map[string]string
+	configShadowed map[string][]ValueOrigin
+	constraints    []*constraint
+	positionals    []*Positional
+
+	values      map[string][]interface{}
+	valueErrors map[string]error
+
 	specifiedRests []string
 	configRests    []string
 
@@ -88,4 +152,9 @@ type ParseResult struct {
 
 	specifiedUndefs []string
 	configUndefs    []string
+
+	parseErrors  []*ParseError
+	undefCommand *ParseError
+
+	levelResults []*ParseResult
 }
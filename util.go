@@ -1,6 +1,10 @@
 package goNixArgParser
 
-import "strconv"
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
 
 func getValue(source map[string][]string, key string) (value string, found bool) {
 	var values []string
@@ -40,6 +44,10 @@ func stringToSlice(input string) []string {
 	return []string{input}
 }
 
+func toString(input string) (string, error) {
+	return input, nil
+}
+
 func toBool(input string) (bool, error) {
 	if len(input) == 0 {
 		return false, nil
@@ -156,3 +164,84 @@ func appendUnique(origins []string, items ...string) []string {
 
 	return origins
 }
+
+func walkStructFields(v reflect.Value, fn func(field reflect.StructField, value reflect.Value) error) error {
+	t := v.Type()
+	for i, l := 0, t.NumField(); i < l; i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := walkStructFields(value, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(field.PkgPath) > 0 { // unexported
+			continue
+		}
+
+		if err := fn(field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func editDistance(a, b string) int {
+	runesA := []rune(a)
+	runesB := []rune(b)
+
+	prev := make([]int, len(runesB)+1)
+	curr := make([]int, len(runesB)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(runesA); i++ {
+		curr[0] = i
+		for j := 1; j <= len(runesB); j++ {
+			cost := 1
+			if runesA[i-1] == runesB[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(runesB)]
+}
+
+func getSuggestions(input string, candidates []string) []string {
+	maxDistance := (len(input) + 1) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	distances := map[string]int{}
+	suggestions := []string{}
+	for _, candidate := range candidates {
+		if _, found := distances[candidate]; found {
+			continue
+		}
+		distance := editDistance(input, candidate)
+		if distance <= maxDistance {
+			distances[candidate] = distance
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return distances[suggestions[i]] < distances[suggestions[j]]
+	})
+	return suggestions
+}
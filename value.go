@@ -0,0 +1,149 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Value interface {
+	Parse(input string) (interface{}, error)
+}
+
+type ValueFunc func(input string) (interface{}, error)
+
+func (f ValueFunc) Parse(input string) (interface{}, error) {
+	return f(input)
+}
+
+var DurationValue ValueFunc = func(input string) (interface{}, error) {
+	return time.ParseDuration(input)
+}
+
+var byteSizeUnits = []struct {
+	suffix string
+	size   uint64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30}, {"tib", 1 << 40}, {"pib", 1 << 50},
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40}, {"pb", 1 << 50},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30}, {"t", 1 << 40}, {"p", 1 << 50},
+	{"b", 1},
+}
+
+var ByteSizeValue ValueFunc = func(input string) (interface{}, error) {
+	text := strings.ToLower(strings.TrimSpace(input))
+	unit := uint64(1)
+	for _, u := range byteSizeUnits {
+		if strings.HasSuffix(text, u.suffix) {
+			text = strings.TrimSpace(text[:len(text)-len(u.suffix)])
+			unit = u.size
+			break
+		}
+	}
+
+	if size, err := strconv.ParseUint(text, 10, 64); err == nil {
+		if size > (1<<64-1)/unit {
+			return nil, errors.New("byte size out of range")
+		}
+		return size * unit, nil
+	}
+
+	size, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, errors.New("invalid byte size")
+	}
+	if size < 0 || size*float64(unit) >= 1<<64 {
+		return nil, errors.New("byte size out of range")
+	}
+	return uint64(size * float64(unit)), nil
+}
+
+var IPValue ValueFunc = func(input string) (interface{}, error) {
+	ip := net.ParseIP(input)
+	if ip == nil {
+		return nil, errors.New("invalid IP address")
+	}
+	return ip, nil
+}
+
+var URLValue ValueFunc = func(input string) (interface{}, error) {
+	return url.Parse(input)
+}
+
+var RegexpValue ValueFunc = func(input string) (interface{}, error) {
+	return regexp.Compile(input)
+}
+
+func EnumValue(choices ...string) Value {
+	return ValueFunc(func(input string) (interface{}, error) {
+		if !contains(choices, input) {
+			return nil, errors.New("must be one of: " + strings.Join(choices, ", "))
+		}
+		return input, nil
+	})
+}
+
+// =============================
+// parse values
+// =============================
+
+func (r *ParseResult) parseValue(opt *Option) {
+	delete(r.values, opt.Key)
+	delete(r.valueErrors, opt.Key)
+
+	if opt.Value == nil {
+		return
+	}
+
+	strs, source := r.getSourceValues(opt.Key)
+	if source == NoneSource {
+		return
+	}
+
+	values := make([]interface{}, 0, len(strs))
+	for _, str := range strs {
+		value, err := opt.Value.Parse(str)
+		if err != nil {
+			r.valueErrors[opt.Key] = &ValueError{Key: opt.Key, Source: source, Value: str, Err: err}
+			return
+		}
+		values = append(values, value)
+	}
+	r.values[opt.Key] = values
+}
+
+func (r *ParseResult) parseValues(options []*Option) {
+	for _, opt := range options {
+		r.parseValue(opt)
+	}
+}
+
+func GetValue[T any](r *ParseResult, key string) (value T, found bool) {
+	values := r.values[key]
+	if len(values) == 0 {
+		return
+	}
+
+	value, found = values[0].(T)
+	return
+}
+
+func GetValues[T any](r *ParseResult, key string) (values []T, found bool) {
+	anyValues, found := r.values[key]
+	if !found {
+		return
+	}
+
+	values = make([]T, len(anyValues))
+	for i, anyValue := range anyValues {
+		values[i], found = anyValue.(T)
+		if !found {
+			return nil, false
+		}
+	}
+	return
+}
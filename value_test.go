@@ -0,0 +1,90 @@
+package goNixArgParser
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	s := NewSimpleOptionSet()
+	s.Add(Option{
+		Key:          "timeout",
+		Flags:        NewSimpleFlags([]string{"--timeout"}),
+		AcceptValue:  true,
+		OverridePrev: true,
+		Value:        DurationValue,
+	})
+	s.Add(Option{
+		Key:           "size",
+		Flags:         NewSimpleFlags([]string{"--size"}),
+		AcceptValue:   true,
+		DefaultValues: []string{"1.5K"},
+		Value:         ByteSizeValue,
+	})
+	s.Add(Option{
+		Key:         "ips",
+		Flags:       NewSimpleFlags([]string{"--ip"}),
+		AcceptValue: true,
+		MultiValues: true,
+		Value:       IPValue,
+	})
+	s.Add(Option{
+		Key:         "format",
+		Flags:       NewSimpleFlags([]string{"--format"}),
+		AcceptValue: true,
+		Value:       EnumValue("json", "yaml"),
+	})
+
+	r := s.Parse([]string{"--timeout", "3s", "--ip", "127.0.0.1", "::1", "--format", "json"}, nil)
+	if r.HasValueError() {
+		t.Fatal(r.GetValueErrors())
+	}
+
+	timeout, found := GetValue[time.Duration](r, "timeout")
+	if !found || timeout != 3*time.Second {
+		t.Error(timeout)
+	}
+
+	size, _ := GetValue[uint64](r, "size")
+	if size != 1536 {
+		t.Error(size)
+	}
+
+	ips, _ := GetValues[net.IP](r, "ips")
+	if len(ips) != 2 || !ips[1].Equal(net.IPv6loopback) {
+		t.Error(ips)
+	}
+
+	if _, found = GetValue[string](r, "timeout"); found {
+		t.Error("type mismatch should not be found")
+	}
+
+	var dst struct {
+		Timeout time.Duration `arg:"timeout"`
+		Ips     []net.IP      `arg:"ips"`
+	}
+	if err := r.Bind(&dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Timeout != 3*time.Second || len(dst.Ips) != 2 {
+		t.Error(dst)
+	}
+
+	r = s.Parse([]string{"--timeout", "3x", "--format", "xml"}, nil)
+	errs := r.GetValueErrors()
+	if len(errs) != 2 {
+		t.Fatal(errs)
+	}
+	var valueErr *ValueError
+	if !errors.As(errs[0], &valueErr) || valueErr.Key != "format" || valueErr.Value != "xml" {
+		t.Error(errs[0])
+	}
+
+	r = s.Parse([]string{}, nil)
+	r.SetConfigOption("format", "yaml")
+	if format, _ := GetValue[string](r, "format"); format != "yaml" {
+		t.Error(format)
+	}
+}